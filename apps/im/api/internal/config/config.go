@@ -1,6 +1,8 @@
 package config
 
 import (
+	"easy-chat/pkg/storage"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -12,6 +14,9 @@ type Config struct {
 	UserRpc   zrpc.RpcClientConf
 	SocialRpc zrpc.RpcClientConf
 
+	Redisx  redis.RedisConf
+	Storage storage.Config // 附件存储配置
+
 	JwtAuth struct {
 		AccessSecret string
 		//AccessExpire int64
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"easy-chat/apps/im/api/internal/logic"
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func downloadFileHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.DownloadFileReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 附件内容由逻辑层直接写入响应
+		l := logic.NewDownloadFileLogic(r.Context(), svcCtx)
+		if err := l.DownloadFile(&req, w, r); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"easy-chat/apps/im/api/internal/svc"
 
@@ -29,6 +30,16 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/chatlog",
 				Handler: getChatLogHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/chatlog/sync",
+				Handler: syncMessagesHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/chatlog/search",
+				Handler: searchChatLogHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPost,
 				Path:    "/setup/conversation",
@@ -44,8 +55,57 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/conversation",
 				Handler: putConversationsHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/conversation/clear",
+				Handler: clearConversationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/conversation/delete",
+				Handler: deleteConversationHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.JwtAuth.AccessSecret),
+		rest.WithPrefix("/v1/im"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/file/upload",
+				Handler: createUploadHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/file/upload/:uploadId",
+				Handler: getUploadHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPut,
+				Path:    "/file/upload/:uploadId",
+				Handler: uploadChunkHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/file/upload/:uploadId/complete",
+				Handler: completeUploadHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/file/quota",
+				Handler: getFileQuotaHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/file/:fileId",
+				Handler: downloadFileHandler(serverCtx),
+			},
 		},
 		rest.WithJwt(serverCtx.Config.JwtAuth.AccessSecret),
 		rest.WithPrefix("/v1/im"),
+		rest.WithTimeout(300000*time.Millisecond),
+		rest.WithMaxBytes(16777216),
 	)
 }
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ClearConversationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewClearConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ClearConversationLogic {
+	return &ClearConversationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ClearConversation 清空当前用户在会话中的聊天记录。
+//
+// 清空只对当前用户生效，会话的其他成员仍能看到全部消息。
+//
+// 参数:
+//   - req: 请求对象，包含会话ID。
+//
+// 返回值:
+//   - *types.ClearConversationResp: 空响应。
+//   - error: 如果在清空过程中发生错误，则返回具体的错误信息。成功时返回 nil。
+func (l *ClearConversationLogic) ClearConversation(req *types.ClearConversationReq) (resp *types.ClearConversationResp, err error) {
+	_, err = l.svcCtx.ClearConversation(l.ctx, &imclient.ClearConversationReq{
+		UserId:         ctxdata.GetUId(l.ctx),
+		ConversationId: req.ConversationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ClearConversationResp{}, nil
+}
@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CompleteUploadLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCompleteUploadLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CompleteUploadLogic {
+	return &CompleteUploadLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CompleteUpload 完成上传。
+//
+// 全部分片上传后调用，文件以内容的 sha256 作为附件ID保存，聊天消息通过附件ID引用该文件。
+// 图片附件会同时返回摆正后的尺寸与缩略图的附件ID。
+//
+// 参数:
+//   - req: 请求对象，包含上传ID。
+//
+// 返回值:
+//   - *types.FileMeta: 附件的元信息。
+//   - error: 文件未上传完成、空间不足或保存失败时返回错误。
+func (l *CompleteUploadLogic) CompleteUpload(req *types.CompleteUploadReq) (resp *types.FileMeta, err error) {
+	meta, err := l.svcCtx.Store.CompleteUpload(l.ctx, ctxdata.GetUId(l.ctx), req.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.FileMeta{
+		FileId: meta.Id,
+		Name:   meta.Name,
+		Mime:   meta.Mime,
+		Size:   meta.Size,
+		Width:  meta.Width,
+		Height: meta.Height,
+		Thumb:  meta.Thumb,
+	}, nil
+}
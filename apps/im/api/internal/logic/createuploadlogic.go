@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateUploadLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateUploadLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateUploadLogic {
+	return &CreateUploadLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CreateUpload 创建附件的分片上传任务。
+//
+// 创建时检查文件大小与用户的存储空间，客户端随后按返回的分片大小依次上传分片。
+//
+// 参数:
+//   - req: 请求对象，包含文件名、文件大小与 MIME 类型。
+//
+// 返回值:
+//   - *types.UploadResp: 上传任务，包含上传ID与建议的分片大小。
+//   - error: 文件过大、空间不足或创建失败时返回错误。
+func (l *CreateUploadLogic) CreateUpload(req *types.CreateUploadReq) (resp *types.UploadResp, err error) {
+	upload, err := l.svcCtx.Store.CreateUpload(l.ctx, ctxdata.GetUId(l.ctx), req.Name, req.Mime, req.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUploadResp(l.svcCtx, upload), nil
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteConversationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteConversationLogic {
+	return &DeleteConversationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DeleteConversation 从当前用户的会话列表中删除会话。
+//
+// 删除会话会同时清空用户在该会话中的聊天记录，会话中有新消息时会自动重新出现在会话列表中。
+//
+// 参数:
+//   - req: 请求对象，包含会话ID。
+//
+// 返回值:
+//   - *types.DeleteConversationResp: 空响应。
+//   - error: 如果在删除过程中发生错误，则返回具体的错误信息。成功时返回 nil。
+func (l *DeleteConversationLogic) DeleteConversation(req *types.DeleteConversationReq) (resp *types.DeleteConversationResp, err error) {
+	_, err = l.svcCtx.DeleteConversation(l.ctx, &imclient.DeleteConversationReq{
+		UserId:         ctxdata.GetUId(l.ctx),
+		ConversationId: req.ConversationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeleteConversationResp{}, nil
+}
@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DownloadFileLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDownloadFileLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DownloadFileLogic {
+	return &DownloadFileLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DownloadFile 下载附件。
+//
+// 支持 Range 请求，客户端可以分段下载或在中断后继续下载。
+//
+// 参数:
+//   - req: 请求对象，包含附件ID。
+//   - w: 响应写入器。
+//   - r: 原始请求，用于读取 Range 等请求头。
+//
+// 返回值:
+//   - error: 附件不存在时返回错误，此时尚未写入响应。
+func (l *DownloadFileLogic) DownloadFile(req *types.DownloadFileReq, w http.ResponseWriter, r *http.Request) error {
+	obj, meta, err := l.svcCtx.Store.Open(l.ctx, req.FileId)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	if meta.Mime != "" {
+		w.Header().Set("Content-Type", meta.Mime)
+	}
+	// 附件内容不会变化，以附件ID作为 ETag 支持条件请求
+	w.Header().Set("ETag", `"`+meta.Id+`"`)
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	http.ServeContent(flushWriter{w}, r, meta.Name, obj.ModTime(), obj)
+	return nil
+}
+
+// flushWriter 每次写入后立即刷新响应，避免超时中间件将整个文件缓存在内存中。
+type flushWriter struct {
+	http.ResponseWriter
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
+}
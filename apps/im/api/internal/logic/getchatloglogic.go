@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
 	"github.com/jinzhu/copier"
 
 	"easy-chat/apps/im/api/internal/svc"
@@ -29,6 +30,8 @@ func NewGetChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCha
 //
 // 该方法调用服务上下文中的 GetChatLog 方法来从数据源中获取聊天记录。
 // 根据请求中的参数，方法会查询特定会话的聊天记录，并将结果返回给调用方。
+// 聊天记录按游标分页，direction 为 0 时向更早的消息翻页，为 1 时向更新的消息翻页，
+// 为 2 时查询 msgId 所指消息前后的消息；继续翻页时携带返回的 nextCursor 或 prevCursor。
 //
 // 参数:
 //   - req: 请求对象，包含查询聊天记录所需的所有信息。
@@ -40,9 +43,14 @@ func (l *GetChatLogLogic) GetChatLog(req *types.ChatLogReq) (resp *types.ChatLog
 	// 调用服务上下文中的 GetChatLog 方法获取聊天记录
 	data, err := l.svcCtx.GetChatLog(l.ctx, &imclient.GetChatLogReq{
 		ConversationId: req.ConversationId,
+		UserId:         ctxdata.GetUId(l.ctx),
 		StartSendTime:  req.StartSendTime,
 		EndSendTime:    req.EndSendTime,
 		Count:          req.Count,
+		WithRevisions:  req.WithRevisions,
+		Cursor:         req.Cursor,
+		Direction:      req.Direction,
+		MsgId:          req.MsgId,
 	})
 	if err != nil {
 		// 如果获取聊天记录时发生错误，返回 nil 和错误信息
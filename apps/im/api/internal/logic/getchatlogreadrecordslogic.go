@@ -5,7 +5,6 @@ import (
 	"easy-chat/apps/im/rpc/im"
 	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/user/rpc/user"
-	"easy-chat/pkg/bitmap"
 	"easy-chat/pkg/constants"
 
 	"easy-chat/apps/im/api/internal/svc"
@@ -28,27 +27,42 @@ func NewGetChatLogReadRecordsLogic(ctx context.Context, svcCtx *svc.ServiceConte
 	}
 }
 
+// GetChatLogReadRecords 获取消息的已读与未读用户。
+//
+// 群聊的已读用户来自消息上精确记录的已读用户列表，其余的群成员为未读用户；
+// 单聊根据接收者在会话中的已读序号判断是否已读。发送者始终视为已读。
+//
+// 参数:
+//   - req: 请求对象，包含消息ID。
+//
+// 返回值:
+//   - *types.GetChatLogReadRecordsResp: 已读与未读用户的手机号码。
+//   - error: 如果在查询过程中发生错误，则返回具体的错误信息。成功时返回 nil。
 func (l *GetChatLogReadRecordsLogic) GetChatLogReadRecords(req *types.GetChatLogReadRecordsReq) (resp *types.GetChatLogReadRecordsResp, err error) {
-	chatLogs, err := l.svcCtx.Im.GetChatLog(l.ctx, &im.GetChatLogReq{
+	records, err := l.svcCtx.Im.GetReadRecords(l.ctx, &im.GetReadRecordsReq{
 		MsgId: req.MsgId,
 	})
-	if err != nil || len(chatLogs.List) == 0 {
+	if err != nil {
 		return nil, err
 	}
 
 	var (
-		chatlog = chatLogs.List[0]
+		chatlog = records.ChatLog
 		reads   = []string{chatlog.SendId}
 		unreads []string
 		ids     []string
+		readBy  = make(map[string]struct{}, len(records.ReadBy))
 	)
+	for _, uid := range records.ReadBy {
+		readBy[uid] = struct{}{}
+	}
 	// 分别设置已读未读
 	switch constants.ChatType(chatlog.ChatType) {
 	case constants.SingleChatType:
-		if len(chatlog.ReadRecords) == 0 || chatlog.ReadRecords[0] == 0 {
-			unreads = []string{chatlog.RecvId}
-		} else {
+		if _, ok := readBy[chatlog.RecvId]; ok {
 			reads = append(reads, chatlog.RecvId)
+		} else {
+			unreads = []string{chatlog.RecvId}
 		}
 		ids = []string{chatlog.RecvId, chatlog.SendId}
 	case constants.GroupChatType:
@@ -59,13 +73,12 @@ func (l *GetChatLogReadRecordsLogic) GetChatLogReadRecords(req *types.GetChatLog
 			return nil, err
 		}
 
-		aBitmap := bitmap.Load(chatlog.ReadRecords)
 		for _, member := range groupUsers.List {
 			ids = append(ids, member.UserId)
 			if member.UserId == chatlog.SendId {
 				continue
 			}
-			if aBitmap.IsSet(member.UserId) {
+			if _, ok := readBy[member.UserId]; ok {
 				reads = append(reads, member.UserId)
 			} else {
 				unreads = append(unreads, member.UserId)
@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetFileQuotaLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetFileQuotaLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetFileQuotaLogic {
+	return &GetFileQuotaLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetFileQuota 查询当前用户的存储空间用量。
+//
+// 参数:
+//   - req: 请求对象。
+//
+// 返回值:
+//   - *types.GetFileQuotaResp: 已使用的空间与空间上限，单位字节。
+//   - error: 查询失败时返回错误。
+func (l *GetFileQuotaLogic) GetFileQuota(req *types.GetFileQuotaReq) (resp *types.GetFileQuotaResp, err error) {
+	used, err := l.svcCtx.Store.Usage(l.ctx, ctxdata.GetUId(l.ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetFileQuotaResp{
+		Used:  used,
+		Quota: l.svcCtx.Store.Quota(),
+	}, nil
+}
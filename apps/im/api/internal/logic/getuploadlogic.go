@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetUploadLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetUploadLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetUploadLogic {
+	return &GetUploadLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetUpload 查询上传任务。
+//
+// 上传中断后，客户端通过该接口获取已上传的大小，并从该位置继续上传。
+//
+// 参数:
+//   - req: 请求对象，包含上传ID。
+//
+// 返回值:
+//   - *types.UploadResp: 上传任务，包含已上传的大小。
+//   - error: 上传任务不存在或已过期时返回错误。
+func (l *GetUploadLogic) GetUpload(req *types.GetUploadReq) (resp *types.UploadResp, err error) {
+	upload, err := l.svcCtx.Store.GetUpload(l.ctx, ctxdata.GetUId(l.ctx), req.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUploadResp(l.svcCtx, upload), nil
+}
@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SearchChatLogLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSearchChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchChatLogLogic {
+	return &SearchChatLogLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SearchChatLog 在当前用户的会话中搜索聊天记录。
+//
+// 结果按消息从新到旧排列，每条结果附带命中关键词附近的摘要与高亮区间；
+// 当 hasMore 为 true 时，携带返回的 nextCursor 继续拉取下一页。
+//
+// 参数:
+//   - req: 请求对象，包含关键词、可选的会话、发送者、时间范围、消息类型过滤条件以及分页参数。
+//
+// 返回值:
+//   - *types.SearchChatLogResp: 搜索结果，包含命中的聊天记录、摘要与下一页游标。
+//   - error: 如果在搜索过程中发生错误，则返回具体的错误信息。成功时返回 nil。
+func (l *SearchChatLogLogic) SearchChatLog(req *types.SearchChatLogReq) (resp *types.SearchChatLogResp, err error) {
+	data, err := l.svcCtx.SearchChatLog(l.ctx, &imclient.SearchChatLogReq{
+		UserId:         ctxdata.GetUId(l.ctx),
+		Keyword:        req.Keyword,
+		ConversationId: req.ConversationId,
+		SendId:         req.SendId,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		MsgTypes:       req.MsgTypes,
+		Cursor:         req.Cursor,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.SearchChatLogResp
+	copier.Copy(&res, &data)
+
+	return &res, nil
+}
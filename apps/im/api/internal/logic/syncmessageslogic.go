@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncMessagesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSyncMessagesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncMessagesLogic {
+	return &SyncMessagesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SyncMessages 按序号同步会话中缺失的消息。
+//
+// 客户端重连后携带本地已收到的最大序号，拉取离线期间缺失的消息；
+// 当 hasMore 为 true 时，以返回的最后一条消息的序号继续拉取。
+//
+// 参数:
+//   - req: 请求对象，包含会话ID、起始序号与拉取数量。
+//
+// 返回值:
+//   - *types.SyncMessagesResp: 同步结果，包含按序号升序排列的消息列表。
+//   - error: 如果在同步过程中发生错误，则返回具体的错误信息。成功时返回 nil。
+func (l *SyncMessagesLogic) SyncMessages(req *types.SyncMessagesReq) (resp *types.SyncMessagesResp, err error) {
+	data, err := l.svcCtx.SyncMessages(l.ctx, &imclient.SyncMessagesReq{
+		ConversationId: req.ConversationId,
+		UserId:         ctxdata.GetUId(l.ctx),
+		FromSeq:        req.FromSeq,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.SyncMessagesResp
+	copier.Copy(&res, &data)
+
+	return &res, nil
+}
@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/ctxdata"
+	"easy-chat/pkg/storage"
+	"io"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UploadChunkLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUploadChunkLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UploadChunkLogic {
+	return &UploadChunkLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// UploadChunk 上传一个分片。
+//
+// 分片必须从已上传的位置开始，即 offset 等于上传任务当前的已上传大小。
+//
+// 参数:
+//   - req: 请求对象，包含上传ID与分片的偏移量。
+//   - body: 分片内容。
+//
+// 返回值:
+//   - *types.UploadResp: 写入后的上传任务。
+//   - error: 偏移量不一致、上传任务不存在或写入失败时返回错误。
+func (l *UploadChunkLogic) UploadChunk(req *types.UploadChunkReq, body io.Reader) (resp *types.UploadResp, err error) {
+	upload, err := l.svcCtx.Store.WriteChunk(l.ctx, ctxdata.GetUId(l.ctx), req.UploadId, req.Offset, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUploadResp(l.svcCtx, upload), nil
+}
+
+// toUploadResp 将上传任务转换为响应。
+func toUploadResp(svcCtx *svc.ServiceContext, upload *storage.Upload) *types.UploadResp {
+	return &types.UploadResp{
+		UploadId:  upload.Id,
+		Size:      upload.Size,
+		Offset:    upload.Offset,
+		ChunkSize: svcCtx.Store.ChunkSize(),
+	}
+}
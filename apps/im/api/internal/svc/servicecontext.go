@@ -5,6 +5,8 @@ import (
 	"easy-chat/apps/im/rpc/imclient"
 	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/user/rpc/userclient"
+	"easy-chat/pkg/storage"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/zrpc"
 )
 
@@ -14,6 +16,8 @@ type ServiceContext struct {
 	imclient.Im
 	userclient.User
 	socialclient.Social
+
+	Store *storage.Store
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -23,5 +27,7 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		Im:     imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
 		User:   userclient.NewUser(zrpc.MustNewClient(c.UserRpc)),
 		Social: socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
+
+		Store: storage.MustNewStore(c.Storage, redis.MustNewRedis(c.Redisx)),
 	}
 }
@@ -2,14 +2,62 @@
 package types
 
 type ChatLog struct {
-	Id             string `json:"id,omitempty"`
-	ConversationId string `json:"conversationId,omitempty"`
-	SendId         string `json:"sendId,omitempty"`
-	RecvId         string `json:"recvId,omitempty"`
-	MsgType        int32  `json:"msgType,omitempty"`
-	MsgContent     string `json:"msgContent,omitempty"`
-	ChatType       int32  `json:"chatType,omitempty"`
-	SendTime       int64  `json:"SendTime,omitempty"`
+	Id             string             `json:"id,omitempty"`
+	ConversationId string             `json:"conversationId,omitempty"`
+	SendId         string             `json:"sendId,omitempty"`
+	RecvId         string             `json:"recvId,omitempty"`
+	MsgType        int32              `json:"msgType,omitempty"`
+	MsgContent     string             `json:"msgContent,omitempty"`
+	ChatType       int32              `json:"chatType,omitempty"`
+	SendTime       int64              `json:"SendTime,omitempty"`
+	Seq            int64              `json:"seq,omitempty"`
+	Status         int32              `json:"status,omitempty"`
+	EditedAt       int64              `json:"editedAt,omitempty"`
+	Revisions      []*ChatLogRevision `json:"revisions,omitempty"`
+	Payload        *MsgPayload        `json:"payload,omitempty"`
+	ReplyTo        string             `json:"replyTo,omitempty"`
+	Quote          *ChatLogQuote      `json:"quote,omitempty"`
+	Mentions       []string           `json:"mentions,omitempty"`
+	MentionAll     bool               `json:"mentionAll,omitempty"`
+	Reactions      []*Reaction        `json:"reactions,omitempty"`
+	ReadCount      int64              `json:"readCount,omitempty"`
+}
+
+type ChatLogQuote struct {
+	Id         string `json:"id"`
+	SendId     string `json:"sendId"`
+	MsgType    int32  `json:"msgType"`
+	MsgContent string `json:"msgContent"`
+	Recalled   bool   `json:"recalled,omitempty"`
+}
+
+type ChatLogRevision struct {
+	MsgContent string `json:"msgContent"`
+	EditedAt   int64  `json:"editedAt"`
+}
+
+type Reaction struct {
+	Emoji   string   `json:"emoji"`
+	Count   int32    `json:"count"`
+	UserIds []string `json:"userIds"`
+}
+
+type MsgPayload struct {
+	FileId    string  `json:"fileId,omitempty"`
+	Url       string  `json:"url,omitempty"`
+	Thumb     string  `json:"thumb,omitempty"`
+	Width     int32   `json:"width,omitempty"`
+	Height    int32   `json:"height,omitempty"`
+	Name      string  `json:"name,omitempty"`
+	Size      int64   `json:"size,omitempty"`
+	Mime      string  `json:"mime,omitempty"`
+	Duration  int32   `json:"duration,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+	Address   string  `json:"address,omitempty"`
+	CardType  int32   `json:"cardType,omitempty"`
+	TargetId  string  `json:"targetId,omitempty"`
+	Avatar    string  `json:"avatar,omitempty"`
 }
 
 type Conversation struct {
@@ -20,7 +68,15 @@ type Conversation struct {
 	Seq            int64  `json:"seq,omitempty"`
 	Read           int32  `json:"read,omitempty"`
 	Total          int32  `json:"total,omitempty"`
-	Unread         int32  `json:"unread,omitempty"`
+	ToRead         int32  `json:"unread,omitempty"`
+	Mentioned      int32  `json:"mentioned,omitempty"`
+	Pinned         bool   `json:"pinned,omitempty"`
+	PinOrder       int64  `json:"pinOrder,omitempty"`
+	MutedUntil     int64  `json:"mutedUntil,omitempty"`
+	Archived       bool   `json:"archived,omitempty"`
+	DisplayName    string `json:"displayName,omitempty"`
+	ClearedSeq     int64  `json:"clearedSeq,omitempty"`
+	ReadSeq        int64  `json:"readSeq,omitempty"`
 }
 
 type GetChatLogReadRecordsReq struct {
@@ -37,10 +93,104 @@ type ChatLogReq struct {
 	StartSendTime  int64  `json:"startSendTime,omitempty"`
 	EndSendTime    int64  `json:"endSendTime,omitempty"`
 	Count          int64  `json:"count,omitempty"`
+	WithRevisions  bool   `json:"withRevisions,omitempty"`
+	Cursor         string `json:"cursor,omitempty"`
+	Direction      int32  `json:"direction,omitempty"`
+	MsgId          string `json:"msgId,omitempty"`
 }
 
 type ChatLogResp struct {
-	List []*ChatLog `json:"list"`
+	List       []*ChatLog `json:"list"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+	PrevCursor string     `json:"prevCursor,omitempty"`
+	HasMore    bool       `json:"hasMore"`
+}
+
+type SyncMessagesReq struct {
+	ConversationId string `json:"conversationId"`
+	FromSeq        int64  `json:"fromSeq,omitempty"`
+	Limit          int64  `json:"limit,omitempty"`
+}
+
+type SyncMessagesResp struct {
+	List    []*ChatLog `json:"list"`
+	HasMore bool       `json:"hasMore"`
+}
+
+type SearchChatLogReq struct {
+	Keyword        string  `json:"keyword"`
+	ConversationId string  `json:"conversationId,omitempty"`
+	SendId         string  `json:"sendId,omitempty"`
+	StartTime      int64   `json:"startTime,omitempty"`
+	EndTime        int64   `json:"endTime,omitempty"`
+	MsgTypes       []int32 `json:"msgTypes,omitempty"`
+	Cursor         string  `json:"cursor,omitempty"`
+	Limit          int64   `json:"limit,omitempty"`
+}
+
+type Highlight struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+type SearchChatLogHit struct {
+	ChatLog    *ChatLog     `json:"chatLog"`
+	Snippet    string       `json:"snippet"`
+	Highlights []*Highlight `json:"highlights"`
+}
+
+type SearchChatLogResp struct {
+	List       []*SearchChatLogHit `json:"list"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+	HasMore    bool                `json:"hasMore"`
+}
+
+type CreateUploadReq struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	Mime string `json:"mime,optional"`
+}
+
+type UploadResp struct {
+	UploadId  string `json:"uploadId"`
+	Size      int64  `json:"size"`
+	Offset    int64  `json:"offset"`
+	ChunkSize int64  `json:"chunkSize"`
+}
+
+type GetUploadReq struct {
+	UploadId string `path:"uploadId"`
+}
+
+type UploadChunkReq struct {
+	UploadId string `path:"uploadId"`
+	Offset   int64  `form:"offset"`
+}
+
+type CompleteUploadReq struct {
+	UploadId string `path:"uploadId"`
+}
+
+type FileMeta struct {
+	FileId string `json:"fileId"`
+	Name   string `json:"name"`
+	Mime   string `json:"mime"`
+	Size   int64  `json:"size"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Thumb  string `json:"thumb,omitempty"`
+}
+
+type DownloadFileReq struct {
+	FileId string `path:"fileId"`
+}
+
+type GetFileQuotaReq struct {
+}
+
+type GetFileQuotaResp struct {
+	Used  int64 `json:"used"`
+	Quota int64 `json:"quota"`
 }
 
 type GetConversationsReq struct {
@@ -58,6 +208,20 @@ type PutConversationsReq struct {
 type PutConversationsResp struct {
 }
 
+type ClearConversationReq struct {
+	ConversationId string `json:"conversationId"`
+}
+
+type ClearConversationResp struct {
+}
+
+type DeleteConversationReq struct {
+	ConversationId string `json:"conversationId"`
+}
+
+type DeleteConversationResp struct {
+}
+
 type SetUpUserConversationReq struct {
 	SendId   string `json:"sendId,omitempty"`
 	RecvId   string `json:"recvId,omitempty"`
@@ -0,0 +1,72 @@
+package immodels
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidCursor 分页游标无效
+var ErrInvalidCursor = errors.New("invalid chat log cursor")
+
+// ChatLogCursor 聊天记录的分页游标。
+//
+// 同一毫秒内可能有多条消息，仅按发送时间分页会跳过或重复消息，因此游标由发送时间与消息ID共同组成，
+// 聊天记录按 (sendTime, _id) 排序后顺序是唯一确定的。
+type ChatLogCursor struct {
+	SendTime int64
+	ID       primitive.ObjectID
+}
+
+// ChatLogPage 聊天记录的分页条件
+type ChatLogPage struct {
+	Cursor        *ChatLogCursor // 分页游标，结果不包含游标所指的消息；为空时从最新（或最早）的消息开始
+	Newer         bool           // 是否查询比游标更新的消息，默认查询更早的消息
+	AfterSendTime int64          // 发送时间下限（不包含），为 0 时不限制
+	AfterSeq      int64          // 序号下限（不包含），为 0 时不限制
+}
+
+// NewChatLogCursor 根据聊天记录生成分页游标
+func NewChatLogCursor(data *ChatLog) *ChatLogCursor {
+	return &ChatLogCursor{
+		SendTime: data.SendTime,
+		ID:       data.ID,
+	}
+}
+
+// String 将游标编码为对客户端不透明的字符串
+func (c *ChatLogCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.SendTime, 10) + ":" + c.ID.Hex()))
+}
+
+// ParseChatLogCursor 解析 String 编码的分页游标。
+//
+// 参数:
+//   - s: 编码后的游标。
+//
+// 返回:
+//   - *ChatLogCursor: 解析得到的游标。
+//   - error: 游标格式错误时返回 ErrInvalidCursor。
+func ParseChatLogCursor(s string) (*ChatLogCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	sendTime, id, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	var c ChatLogCursor
+	if c.SendTime, err = strconv.ParseInt(sendTime, 10, 64); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
@@ -0,0 +1,24 @@
+package immodels
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChatLogCursor(t *testing.T) {
+	c := &ChatLogCursor{SendTime: 1700000000123, ID: primitive.NewObjectID()}
+	got, err := ParseChatLogCursor(c.String())
+	if err != nil {
+		t.Fatalf("ParseChatLogCursor() err = %v", err)
+	}
+	if *got != *c {
+		t.Errorf("ParseChatLogCursor() = %v, want %v", got, c)
+	}
+
+	for _, s := range []string{"", "!!", "MTIz", c.ID.Hex()} {
+		if _, err := ParseChatLogCursor(s); err != ErrInvalidCursor {
+			t.Errorf("ParseChatLogCursor(%q) err = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
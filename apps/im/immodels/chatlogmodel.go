@@ -1,6 +1,15 @@
 package immodels
 
-import "github.com/zeromicro/go-zero/core/stores/mon"
+import (
+	"context"
+	"easy-chat/pkg/constants"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
 
 var _ ChatLogModel = (*customChatLogModel)(nil)
 
@@ -9,6 +18,15 @@ type (
 	// and implement the added methods in customChatLogModel.
 	ChatLogModel interface {
 		chatLogModel
+		ListByCursor(ctx context.Context, conversationId string, page *ChatLogPage, limit int64) ([]*ChatLog, error)
+		ListBySeq(ctx context.Context, conversationId string, fromSeq, limit int64) ([]*ChatLog, error)
+		CountMentions(ctx context.Context, conversationId, userId string, afterSeq int64) (int64, error)
+		Search(ctx context.Context, search *ChatLogSearch, limit int64) ([]*ChatLog, error)
+		MarkRead(ctx context.Context, id primitive.ObjectID, userId string) (int64, bool, error)
+		MigrateReadRecords(ctx context.Context, id primitive.ObjectID, readBy []string) error
+		UpdateStatus(ctx context.Context, id primitive.ObjectID, status constants.MsgStatus) error
+		UpdateContent(ctx context.Context, data *ChatLog, content string, editedAt int64) error
+		UpdateReaction(ctx context.Context, id primitive.ObjectID, emoji, userId string, add bool) (*ChatLog, error)
 	}
 
 	customChatLogModel struct {
@@ -19,6 +37,7 @@ type (
 // NewChatLogModel returns a model for the mongo.
 func NewChatLogModel(url, db, collection string) ChatLogModel {
 	conn := mon.MustNewModel(url, db, collection)
+	ensureIndexes(conn)
 	return &customChatLogModel{
 		defaultChatLogModel: newDefaultChatLogModel(conn),
 	}
@@ -27,3 +46,205 @@ func NewChatLogModel(url, db, collection string) ChatLogModel {
 func MustChatLogModel(url, db string) ChatLogModel {
 	return NewChatLogModel(url, db, "chat_log")
 }
+
+// 插入聊天记录，同时写入全文搜索的分词
+func (m *customChatLogModel) Insert(ctx context.Context, data *ChatLog) error {
+	data.Tokens = chatLogTokens(data)
+	return m.defaultChatLogModel.Insert(ctx, data)
+}
+
+// 按游标分页查询会话中的聊天记录，查询更早的消息时按 (sendTime, _id) 倒序排列，查询更新的消息时正序排列
+func (m *customChatLogModel) ListByCursor(ctx context.Context, conversationId string, page *ChatLogPage, limit int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	if limit <= 0 {
+		limit = DefaultChatLogLimit
+	}
+	order, cmp := -1, "$lt"
+	if page.Newer {
+		order, cmp = 1, "$gt"
+	}
+	opt := options.Find().SetLimit(limit).SetSort(bson.D{
+		{Key: "sendTime", Value: order},
+		{Key: "_id", Value: order},
+	})
+
+	filter := bson.M{
+		"conversationId": conversationId,
+	}
+	if page.Cursor != nil {
+		filter["$or"] = bson.A{
+			bson.M{"sendTime": bson.M{cmp: page.Cursor.SendTime}},
+			bson.M{"sendTime": page.Cursor.SendTime, "_id": bson.M{cmp: page.Cursor.ID}},
+		}
+	}
+	if page.AfterSendTime > 0 {
+		filter["sendTime"] = bson.M{"$gt": page.AfterSendTime}
+	}
+	if page.AfterSeq > 0 {
+		filter["seq"] = bson.M{"$gt": page.AfterSeq}
+	}
+
+	err := m.conn.Find(ctx, &data, filter, opt)
+	switch err {
+	case nil:
+		return data, nil
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// 按序号查询会话中 fromSeq 之后的聊天记录，结果按序号升序排列
+func (m *customChatLogModel) ListBySeq(ctx context.Context, conversationId string, fromSeq, limit int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	opt := options.FindOptions{
+		Limit: &DefaultChatLogLimit,
+		Sort: bson.M{
+			"seq": 1,
+		},
+	}
+	if limit > 0 {
+		opt.Limit = &limit
+	}
+
+	filter := bson.M{
+		"conversationId": conversationId,
+		"seq": bson.M{
+			"$gt": fromSeq,
+		},
+	}
+	err := m.conn.Find(ctx, &data, filter, &opt)
+	switch err {
+	case nil:
+		return data, nil
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// CountMentions 统计会话中 afterSeq 之后@了指定用户（包括@所有人）的消息数，不含用户自己发送与已撤回的消息。
+func (m *customChatLogModel) CountMentions(ctx context.Context, conversationId, userId string, afterSeq int64) (int64, error) {
+	filter := bson.M{
+		"conversationId": conversationId,
+		"seq":            bson.M{"$gt": afterSeq},
+		"sendId":         bson.M{"$ne": userId},
+		"status":         bson.M{"$ne": constants.RecalledMsgStatus},
+		"$or": bson.A{
+			bson.M{"mentions": userId},
+			bson.M{"mentionAll": true},
+		},
+	}
+	return m.conn.CountDocuments(ctx, filter)
+}
+
+// 将用户记录为已读该消息，返回消息的已读人数以及是否为新增的已读记录
+func (m *customChatLogModel) MarkRead(ctx context.Context, id primitive.ObjectID, userId string) (int64, bool, error) {
+	var data ChatLog
+	err := m.conn.FindOneAndUpdate(ctx, &data, bson.M{
+		"_id":    id,
+		"readBy": bson.M{"$ne": userId},
+	}, bson.M{
+		"$push": bson.M{"readBy": userId},
+		"$inc":  bson.M{"readCount": 1},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"readCount": 1}))
+	switch err {
+	case nil:
+		return data.ReadCount, true, nil
+	case mon.ErrNotFound:
+		// 消息不存在或用户已读
+		return 0, false, nil
+	default:
+		return 0, false, err
+	}
+}
+
+// 将旧版本的位图已读记录迁移为已读用户列表，消息已迁移时不做任何操作
+func (m *customChatLogModel) MigrateReadRecords(ctx context.Context, id primitive.ObjectID, readBy []string) error {
+	_, err := m.conn.UpdateOne(ctx, bson.M{
+		"_id":    id,
+		"readBy": bson.M{"$exists": false},
+	}, bson.M{
+		"$set":   bson.M{"readBy": readBy, "readCount": len(readBy)},
+		"$unset": bson.M{"readRecords": ""},
+	})
+	return err
+}
+
+// 更新消息状态，例如撤回
+func (m *customChatLogModel) UpdateStatus(ctx context.Context, id primitive.ObjectID, status constants.MsgStatus) error {
+	_, err := m.conn.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"status":   status,
+		"updateAt": time.Now(),
+	}})
+	return err
+}
+
+// 编辑消息内容，并将编辑前的内容记录为历史版本
+//
+// 仅当消息内容仍为 data.MsgContent 时才会更新，避免并发编辑时丢失历史版本
+func (m *customChatLogModel) UpdateContent(ctx context.Context, data *ChatLog, content string, editedAt int64) error {
+	revision := &ChatLogRevision{
+		MsgContent: data.MsgContent,
+		EditedAt:   data.EditedAt,
+	}
+	if revision.EditedAt == 0 {
+		revision.EditedAt = data.SendTime
+	}
+
+	res, err := m.conn.UpdateOne(ctx, bson.M{"_id": data.ID, "msgContent": data.MsgContent}, bson.M{
+		"$set": bson.M{
+			"msgContent": content,
+			"tokens":     SearchTokens(content, false),
+			"editedAt":   editedAt,
+			"updateAt":   time.Now(),
+		},
+		"$push": bson.M{"revisions": revision},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	data.Revisions = append(data.Revisions, revision)
+	data.MsgContent = content
+	data.EditedAt = editedAt
+	return nil
+}
+
+// 添加或移除用户的表情回应，返回更新后的消息
+//
+// 同一用户对同一表情只记录一次，移除后没有用户回应的表情会被删除
+func (m *customChatLogModel) UpdateReaction(ctx context.Context, id primitive.ObjectID, emoji, userId string, add bool) (*ChatLog, error) {
+	field := "reactions." + emoji
+	update := bson.M{"$pull": bson.M{field: userId}}
+	if add {
+		update = bson.M{"$addToSet": bson.M{field: userId}}
+	}
+
+	var data ChatLog
+	err := m.conn.FindOneAndUpdate(ctx, &data, bson.M{"_id": id}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After))
+	switch err {
+	case nil:
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+
+	if !add && len(data.Reactions[emoji]) == 0 {
+		_, err = m.conn.UpdateOne(ctx, bson.M{"_id": id, field: bson.M{"$size": 0}}, bson.M{"$unset": bson.M{field: ""}})
+		if err != nil {
+			return nil, err
+		}
+		delete(data.Reactions, emoji)
+	}
+	return &data, nil
+}
@@ -0,0 +1,39 @@
+package immodels
+
+import (
+	"easy-chat/pkg/bitmap"
+	"easy-chat/pkg/constants"
+)
+
+// HasLegacyReadRecords 判断消息的已读信息是否仍为旧版本的位图格式
+func (c *ChatLog) HasLegacyReadRecords() bool {
+	return len(c.ReadRecords) > 0 && c.ReadBy == nil
+}
+
+// LegacyReadBy 根据旧版本的位图已读记录推算已读的用户。
+//
+// 旧版本将用户ID哈希到固定大小的位图中，不同用户可能冲突，推算结果可能包含实际未读的用户，
+// 因此只用于迁移旧数据，迁移后的已读记录是精确的。
+//
+// 参数:
+//   - members: 群成员ID，单聊时忽略。
+//
+// 返回:
+//   - []string: 已读的用户ID，不包含发送者。
+func (c *ChatLog) LegacyReadBy(members []string) []string {
+	readBy := make([]string, 0)
+	switch c.ChatType {
+	case constants.SingleChatType:
+		if len(c.ReadRecords) > 0 && c.ReadRecords[0] != 0 {
+			readBy = append(readBy, c.RecvId)
+		}
+	case constants.GroupChatType:
+		records := bitmap.Load(c.ReadRecords)
+		for _, uid := range members {
+			if uid != c.SendId && records.IsSet(uid) {
+				readBy = append(readBy, uid)
+			}
+		}
+	}
+	return readBy
+}
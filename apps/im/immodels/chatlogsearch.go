@@ -0,0 +1,194 @@
+package immodels
+
+import (
+	"context"
+	"easy-chat/pkg/constants"
+	"strings"
+	"unicode"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// maxSearchTokens 单条消息最多保存的索引词数量
+	maxSearchTokens = 512
+	// maxPrefixLen 英文单词建立前缀索引的最大长度
+	maxPrefixLen = 20
+)
+
+// ChatLogSearch 聊天记录的搜索条件
+type ChatLogSearch struct {
+	ConversationIds []string           // 限定搜索的会话
+	ClearedSeqs     map[string]int64   // 用户清空过聊天记录的会话，只搜索序号大于该值的消息
+	Tokens          []string           // 关键词的索引词，消息需包含全部索引词
+	SendId          string             // 发送者
+	StartTime       int64              // 发送时间下限（包含），为 0 时不限制
+	EndTime         int64              // 发送时间上限（不包含），为 0 时不限制
+	MsgTypes        []constants.MType  // 消息类型，为空时不限制
+	Before          primitive.ObjectID // 分页游标，只返回ID小于该值的消息
+}
+
+// SearchTokens 对文本分词，得到用于全文搜索的索引词。
+//
+// MongoDB 的文本索引不支持中文分词，聊天记录使用内嵌的倒排索引：
+// 中日韩文字同时按单字与相邻两字切分，其他文字按单词切分并转为小写，单词额外保存长度至少为 2 的前缀以支持前缀匹配。
+// 对关键词分词时 query 为 true，此时中日韩文字只在单独一个字时使用单字，单词不展开前缀。
+//
+// 参数:
+//   - text: 要分词的文本。
+//   - query: 是否为搜索关键词分词。
+//
+// 返回:
+//   - []string: 去重后的索引词。
+func SearchTokens(text string, query bool) []string {
+	seen := make(map[string]struct{})
+	tokens := make([]string, 0)
+	add := func(token string) {
+		if _, ok := seen[token]; ok || len(tokens) >= maxSearchTokens {
+			return
+		}
+		seen[token] = struct{}{}
+		tokens = append(tokens, token)
+	}
+
+	var (
+		word []rune // 当前的单词
+		cjk  []rune // 当前连续的中日韩文字
+	)
+	flushWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		if query {
+			add(string(word))
+		} else {
+			for i := 2; i <= len(word) && i <= maxPrefixLen; i++ {
+				add(string(word[:i]))
+			}
+			add(string(word))
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		if len(cjk) == 0 {
+			return
+		}
+		if !query || len(cjk) == 1 {
+			for _, r := range cjk {
+				add(string(r))
+			}
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			add(string(cjk[i : i+2]))
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// isCJK 判断字符是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// chatLogTokens 计算聊天记录的索引词，包括消息内容以及附件的文件名、地点名称与地址
+func chatLogTokens(data *ChatLog) []string {
+	text := data.MsgContent
+	if p := data.Payload; p != nil {
+		text = strings.Join([]string{text, p.Name, p.Address}, " ")
+	}
+	return SearchTokens(text, false)
+}
+
+// ensureIndexes 创建聊天记录的索引，索引已存在时不做任何操作
+func ensureIndexes(coll interface{ Indexes() mongo.IndexView }) {
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "conversationId", Value: 1}, {Key: "seq", Value: 1}}},
+		{Keys: bson.D{{Key: "conversationId", Value: 1}, {Key: "sendTime", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "tokens", Value: 1}, {Key: "_id", Value: -1}}},
+	})
+	if err != nil {
+		logx.Errorf("create chat_log indexes err: %v", err)
+	}
+}
+
+// 全文搜索聊天记录，已撤回的消息不参与搜索，结果按消息ID倒序排列
+func (m *customChatLogModel) Search(ctx context.Context, search *ChatLogSearch, limit int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	filter := bson.M{
+		"conversationId": bson.M{"$in": search.ConversationIds},
+		"status":         bson.M{"$ne": constants.RecalledMsgStatus},
+	}
+	if len(search.ClearedSeqs) > 0 {
+		// 清空过聊天记录的会话需要单独限定序号
+		ids := make([]string, 0, len(search.ConversationIds))
+		or := make(bson.A, 0, len(search.ClearedSeqs)+1)
+		for _, id := range search.ConversationIds {
+			seq, ok := search.ClearedSeqs[id]
+			if !ok {
+				ids = append(ids, id)
+				continue
+			}
+			or = append(or, bson.M{"conversationId": id, "seq": bson.M{"$gt": seq}})
+		}
+		or = append(or, bson.M{"conversationId": bson.M{"$in": ids}})
+		delete(filter, "conversationId")
+		filter["$or"] = or
+	}
+	if len(search.Tokens) > 0 {
+		filter["tokens"] = bson.M{"$all": search.Tokens}
+	}
+	if search.SendId != "" {
+		filter["sendId"] = search.SendId
+	}
+	if len(search.MsgTypes) > 0 {
+		filter["msgType"] = bson.M{"$in": search.MsgTypes}
+	}
+	sendTime := bson.M{}
+	if search.StartTime > 0 {
+		sendTime["$gte"] = search.StartTime
+	}
+	if search.EndTime > 0 {
+		sendTime["$lt"] = search.EndTime
+	}
+	if len(sendTime) > 0 {
+		filter["sendTime"] = sendTime
+	}
+	if !search.Before.IsZero() {
+		filter["_id"] = bson.M{"$lt": search.Before}
+	}
+
+	opt := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(limit).
+		SetProjection(bson.M{"tokens": 0, "revisions": 0})
+	err := m.conn.Find(ctx, &data, filter, opt)
+	switch err {
+	case nil:
+		return data, nil
+	case ErrNotFound:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,26 @@
+package immodels
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchTokens(t *testing.T) {
+	tests := []struct {
+		text  string
+		query bool
+		want  []string
+	}{
+		{"你好世界", false, []string{"你", "好", "世", "界", "你好", "好世", "世界"}},
+		{"你好世界", true, []string{"你好", "好世", "世界"}},
+		{"好", true, []string{"好"}},
+		{"Hello, 世界", false, []string{"he", "hel", "hell", "hello", "世", "界", "世界"}},
+		{"Hello", true, []string{"hello"}},
+		{"...", true, []string{}},
+	}
+	for _, tt := range tests {
+		if got := SearchTokens(tt.text, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SearchTokens(%q, %v) = %v, want %v", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
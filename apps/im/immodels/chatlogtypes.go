@@ -11,18 +11,55 @@ var DefaultChatLogLimit int64 = 100
 type ChatLog struct {
 	ID primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 
-	ConversationId string             `bson:"conversationId"`
-	SendId         string             `bson:"sendId"`
-	RecvId         string             `bson:"recvId"`
-	MsgFrom        int                `bson:"msgFrom"`
-	ChatType       constants.ChatType `bson:"chatType"`
-	MsgType        constants.MType    `bson:"msgType"`
-	MsgContent     string             `bson:"msgContent"`
-	SendTime       int64              `bson:"sendTime"`
-	Status         int                `bson:"status"`
-	ReadRecords    []byte             `bson:"readRecords"` // 记录该消息的已读信息
+	ConversationId string              `bson:"conversationId"`
+	Seq            int64               `bson:"seq"` // 消息在会话内的序号，严格递增
+	SendId         string              `bson:"sendId"`
+	RecvId         string              `bson:"recvId"`
+	MsgFrom        int                 `bson:"msgFrom"`
+	ChatType       constants.ChatType  `bson:"chatType"`
+	MsgType        constants.MType     `bson:"msgType"`
+	MsgContent     string              `bson:"msgContent"`
+	Payload        *MsgPayload         `bson:"payload,omitempty"`    // 非文本消息的结构化内容
+	ReplyTo        string              `bson:"replyTo,omitempty"`    // 引用回复的消息ID，属于同一会话
+	Mentions       []string            `bson:"mentions,omitempty"`   // 群聊中@的成员ID
+	MentionAll     bool                `bson:"mentionAll,omitempty"` // 群聊中@所有人
+	Reactions      map[string][]string `bson:"reactions,omitempty"`  // 表情回应，键为表情，值为回应的用户ID
+	Tokens         []string            `bson:"tokens,omitempty"`     // 全文搜索的索引词，由消息内容生成
+	SendTime       int64               `bson:"sendTime"`
+	Status         constants.MsgStatus `bson:"status"`
+	ReadRecords    []byte              `bson:"readRecords,omitempty"` // 已废弃：旧版本基于哈希位图的已读信息，仅在迁移时读取
+	ReadBy         []string            `bson:"readBy,omitempty"`      // 已读该消息的用户ID，不包含发送者
+	ReadCount      int64               `bson:"readCount,omitempty"`   // 已读该消息的用户数，与 ReadBy 的长度一致
+
+	EditedAt  int64              `bson:"editedAt,omitempty"`  // 最后一次编辑的时间戳，未编辑过为 0
+	Revisions []*ChatLogRevision `bson:"revisions,omitempty"` // 编辑前的历史版本，按时间先后排列
 
 	// TODO: Fill your own fields
 	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
 	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
 }
+
+// ChatLogRevision 消息的历史版本
+type ChatLogRevision struct {
+	MsgContent string `bson:"msgContent"`
+	EditedAt   int64  `bson:"editedAt"` // 该版本内容的产生时间，原始版本为消息的发送时间
+}
+
+// MsgPayload 非文本消息的结构化内容，不同类型的消息使用其中不同的字段
+type MsgPayload struct {
+	FileId    string             `bson:"fileId,omitempty"`
+	Url       string             `bson:"url,omitempty"`
+	Thumb     string             `bson:"thumb,omitempty"`
+	Width     int32              `bson:"width,omitempty"`
+	Height    int32              `bson:"height,omitempty"`
+	Name      string             `bson:"name,omitempty"`
+	Size      int64              `bson:"size,omitempty"`
+	Mime      string             `bson:"mime,omitempty"`
+	Duration  int32              `bson:"duration,omitempty"`
+	Latitude  float64            `bson:"latitude,omitempty"`
+	Longitude float64            `bson:"longitude,omitempty"`
+	Address   string             `bson:"address,omitempty"`
+	CardType  constants.CardType `bson:"cardType,omitempty"`
+	TargetId  string             `bson:"targetId,omitempty"`
+	Avatar    string             `bson:"avatar,omitempty"`
+}
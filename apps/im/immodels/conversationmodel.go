@@ -1,6 +1,14 @@
 package immodels
 
-import "github.com/zeromicro/go-zero/core/stores/mon"
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
 
 var _ ConversationModel = (*customConversationModel)(nil)
 
@@ -9,6 +17,8 @@ type (
 	// and implement the added methods in customConversationModel.
 	ConversationModel interface {
 		conversationModel
+		UpdateLastMsg(ctx context.Context, chatLog *ChatLog) error
+		IncrSeq(ctx context.Context, conversationId string, msgId primitive.ObjectID) (int64, error)
 	}
 
 	customConversationModel struct {
@@ -27,3 +37,79 @@ func NewConversationModel(url, db, collection string) ConversationModel {
 func MustConversationModel(url, db string) ConversationModel {
 	return NewConversationModel(url, db, "conversation")
 }
+
+// UpdateLastMsg 当会话的最新消息为该消息时，同步更新最新消息的内容与状态（撤回、编辑）
+func (m *customConversationModel) UpdateLastMsg(ctx context.Context, chatLog *ChatLog) error {
+	_, err := m.conn.UpdateOne(ctx,
+		bson.M{"conversationId": chatLog.ConversationId, "msg._id": chatLog.ID},
+		bson.M{"$set": bson.M{
+			"msg.msgContent": chatLog.MsgContent,
+			"msg.status":     chatLog.Status,
+			"msg.editedAt":   chatLog.EditedAt,
+		}},
+	)
+	return err
+}
+
+// IncrSeq 为会话中的消息分配下一个消息序号。
+//
+// 通过原子递增会话的 seq 分配序号，保证同一会话内的序号严格递增。会话同时记录最近 SeqAllocLimit 条消息分配的序号，
+// 同一消息重复分配（例如消费失败后重试）时返回之前分配的序号，避免重试在会话的序号中留下空洞。
+// 会话不存在时返回 ErrNotFound，不会创建会话记录。
+func (m *customConversationModel) IncrSeq(ctx context.Context, conversationId string, msgId primitive.ObjectID) (int64, error) {
+	// 消息已分配过序号时直接复用
+	seq, err := m.findSeq(ctx, conversationId, msgId)
+	if err != ErrNotFound {
+		return seq, err
+	}
+
+	var data Conversation
+	err = m.conn.FindOneAndUpdate(ctx, &data,
+		bson.M{"conversationId": conversationId, "seqAllocs.msgId": bson.M{"$ne": msgId}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"seq": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$seq", 0}}, 1}},
+			}}},
+			{{Key: "$set", Value: bson.M{
+				"seqAllocs": bson.M{"$slice": bson.A{
+					bson.M{"$concatArrays": bson.A{
+						bson.M{"$ifNull": bson.A{"$seqAllocs", bson.A{}}},
+						bson.A{bson.M{"msgId": msgId, "seq": "$seq"}},
+					}},
+					-SeqAllocLimit,
+				}},
+			}}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"seq": 1}),
+	)
+	switch err {
+	case nil:
+		return data.Seq, nil
+	case mon.ErrNotFound:
+		// 会话不存在，或者同一消息的并发重试已经分配了序号
+		return m.findSeq(ctx, conversationId, msgId)
+	default:
+		return 0, err
+	}
+}
+
+// findSeq 查询会话最近为消息分配的序号，未分配时返回 ErrNotFound
+func (m *customConversationModel) findSeq(ctx context.Context, conversationId string, msgId primitive.ObjectID) (int64, error) {
+	var data Conversation
+
+	err := m.conn.FindOne(ctx, &data,
+		bson.M{"conversationId": conversationId, "seqAllocs.msgId": msgId},
+		options.FindOne().SetProjection(bson.M{"seqAllocs.$": 1}),
+	)
+	switch err {
+	case nil:
+	case mon.ErrNotFound:
+		return 0, ErrNotFound
+	default:
+		return 0, err
+	}
+	if len(data.SeqAllocs) == 0 {
+		return 0, ErrNotFound
+	}
+	return data.SeqAllocs[0].Seq, nil
+}
@@ -1,6 +1,13 @@
 package immodels
 
-import "github.com/zeromicro/go-zero/core/stores/mon"
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
 
 var _ ConversationsModel = (*customConversationsModel)(nil)
 
@@ -9,6 +16,10 @@ type (
 	// and implement the added methods in customConversationsModel.
 	ConversationsModel interface {
 		conversationsModel
+		ListMutedUserIds(ctx context.Context, conversationId string, userIds []string, now int64) ([]string, error)
+		UpdateReadSeq(ctx context.Context, uid, conversationId string, readSeq int64) (bool, error)
+		PutConversations(ctx context.Context, uid string, conversations map[string]*Conversation) error
+		ClearConversation(ctx context.Context, uid, conversationId string, seq int64, total int, deleted bool) error
 	}
 
 	customConversationsModel struct {
@@ -27,3 +38,113 @@ func NewConversationsModel(url, db, collection string) ConversationsModel {
 func MustConversationsModel(url, db string) ConversationsModel {
 	return NewConversationsModel(url, db, "conversations")
 }
+
+// 查询 userIds 中在 now 时刻对会话开启了免打扰的用户
+func (m *customConversationsModel) ListMutedUserIds(ctx context.Context, conversationId string, userIds []string, now int64) ([]string, error) {
+	var data []*Conversations
+
+	field := "conversationList." + conversationId + ".mutedUntil"
+	filter := bson.M{
+		"userId": bson.M{"$in": userIds},
+		"$or": bson.A{
+			bson.M{field: MutedForever},
+			bson.M{field: bson.M{"$gt": now}},
+		},
+	}
+	opt := options.Find().SetProjection(bson.M{"userId": 1})
+
+	if err := m.conn.Find(ctx, &data, filter, opt); err != nil && err != mon.ErrNotFound {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(data))
+	for _, v := range data {
+		res = append(res, v.UserId)
+	}
+	return res, nil
+}
+
+// 将用户在会话中的已读序号推进到 readSeq，已读序号只增不减，返回已读序号是否发生变化
+func (m *customConversationsModel) UpdateReadSeq(ctx context.Context, uid, conversationId string, readSeq int64) (bool, error) {
+	key := "conversationList." + conversationId
+	res, err := m.conn.UpdateOne(ctx, bson.M{
+		"userId": uid,
+		key:      bson.M{"$exists": true},
+	}, bson.M{
+		"$max": bson.M{key + ".readSeq": readSeq},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// 按字段更新用户会话列表中的会话信息与个人设置，用户的会话列表不存在时返回 ErrNotFound
+//
+// 会话的 Total 为新增的已读消息数，累加到原值上；已读序号只增不减。
+// 清空位置、删除标记等未由客户端提交的字段保持不变，避免覆盖并发的已读、清空等更新。
+func (m *customConversationsModel) PutConversations(ctx context.Context, uid string, conversations map[string]*Conversation) error {
+	if len(conversations) == 0 {
+		return nil
+	}
+
+	res, err := m.conn.UpdateOne(ctx, bson.M{"userId": uid}, putConversationsUpdate(conversations))
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// putConversationsUpdate 生成按字段更新会话列表的更新文档
+func putConversationsUpdate(conversations map[string]*Conversation) bson.M {
+	var (
+		set    = bson.M{"updateAt": time.Now()}
+		inc    = bson.M{}
+		maxSeq = bson.M{}
+	)
+	for id, c := range conversations {
+		key := "conversationList." + id + "."
+		set[key+"conversationId"] = c.ConversationId
+		set[key+"chatType"] = c.ChatType
+		set[key+"isShow"] = c.IsShow
+		set[key+"seq"] = c.Seq
+		set[key+"pinned"] = c.Pinned
+		set[key+"pinOrder"] = c.PinOrder
+		set[key+"mutedUntil"] = c.MutedUntil
+		set[key+"archived"] = c.Archived
+		set[key+"displayName"] = c.DisplayName
+		inc[key+"total"] = c.Total
+		maxSeq[key+"readSeq"] = c.ReadSeq
+	}
+	return bson.M{"$set": set, "$inc": inc, "$max": maxSeq}
+}
+
+// 清空用户会话的聊天记录，用户的会话列表中没有该会话时返回 ErrNotFound
+//
+// 将清空位置设为会话当前的最大序号 seq，已读序号、会话序号与消息总数只增不减，deleted 为 true 时同时删除会话。
+// 只更新这些字段，避免覆盖并发的已读与个人设置更新。
+func (m *customConversationsModel) ClearConversation(ctx context.Context, uid, conversationId string, seq int64, total int, deleted bool) error {
+	key := "conversationList." + conversationId
+	set := bson.M{key + ".clearedSeq": seq, "updateAt": time.Now()}
+	if deleted {
+		set[key+".deleted"] = true
+	}
+
+	res, err := m.conn.UpdateOne(ctx, bson.M{
+		"userId": uid,
+		key:      bson.M{"$exists": true},
+	}, bson.M{
+		"$set": set,
+		"$max": bson.M{key + ".readSeq": seq, key + ".seq": seq, key + ".total": total},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
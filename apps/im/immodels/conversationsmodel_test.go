@@ -0,0 +1,29 @@
+package immodels
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestPutConversationsUpdate(t *testing.T) {
+	update := putConversationsUpdate(map[string]*Conversation{
+		"c1": {ConversationId: "c1", Seq: 9, Total: 2, ReadSeq: 5, Pinned: true, PinOrder: 3, Deleted: true, ClearedSeq: 4},
+	})
+
+	set := update["$set"].(bson.M)
+	if set["conversationList.c1.pinOrder"] != int64(3) || set["conversationList.c1.seq"] != int64(9) {
+		t.Errorf("$set = %v, want settings of c1", set)
+	}
+	for _, field := range []string{"deleted", "clearedSeq", "readSeq", "total"} {
+		if _, ok := set["conversationList.c1."+field]; ok {
+			t.Errorf("$set should not overwrite %s", field)
+		}
+	}
+	if got := update["$inc"].(bson.M)["conversationList.c1.total"]; got != 2 {
+		t.Errorf("$inc total = %v, want 2", got)
+	}
+	if got := update["$max"].(bson.M)["conversationList.c1.readSeq"]; got != int64(5) {
+		t.Errorf("$max readSeq = %v, want 5", got)
+	}
+}
@@ -7,6 +7,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	// MutedForever 永久免打扰
+	MutedForever int64 = -1
+	// SeqAllocLimit 会话中保留的最近消息序号分配记录数
+	SeqAllocLimit = 100
+)
+
 type Conversation struct {
 	ID primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 
@@ -18,6 +25,42 @@ type Conversation struct {
 	Seq    int64    `bson:"seq"`
 	Msg    *ChatLog `bson:"msg,omitempty"`
 
+	SeqAllocs []*SeqAlloc `bson:"seqAllocs,omitempty" json:"-"` // 最近为消息分配的序号，消息重试时复用
+
+	// 以下为用户对会话的个人设置，只保存在用户的会话列表中
+	Pinned      bool   `bson:"pinned,omitempty"`      // 是否置顶
+	PinOrder    int64  `bson:"pinOrder,omitempty"`    // 置顶顺序，值越大越靠前
+	MutedUntil  int64  `bson:"mutedUntil,omitempty"`  // 免打扰截止时间（毫秒），为 MutedForever 时永久免打扰
+	Archived    bool   `bson:"archived,omitempty"`    // 是否归档
+	DisplayName string `bson:"displayName,omitempty"` // 用户自定义的会话名称
+	ClearedSeq  int64  `bson:"clearedSeq,omitempty"`  // 用户清空聊天记录时会话的最大序号，不超过该序号的消息对用户不可见
+	ReadSeq     int64  `bson:"readSeq,omitempty"`     // 用户已读到的消息序号，未读数为会话的最大序号减去该值
+	Deleted     bool   `bson:"deleted,omitempty"`     // 用户删除了会话，会话中有新消息时重新显示
+
 	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
 	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
 }
+
+// SeqAlloc 会话为消息分配的序号
+type SeqAlloc struct {
+	MsgId primitive.ObjectID `bson:"msgId"`
+	Seq   int64              `bson:"seq"`
+}
+
+// UnreadCount 计算用户会话的未读消息数。
+//
+// c 为用户会话列表中的会话，conversation 为会话本身。未读数为会话的最大序号减去用户的已读位置，
+// 已读位置取已读序号与清空位置中的较大者；未记录过已读序号的会话按消息总数之差计算，以兼容旧数据。
+//
+// 参数:
+//   - conversation: 会话本身，包含会话的最大序号与消息总数。
+//
+// 返回:
+//   - int64: 未读消息数，不小于 0。
+func (c *Conversation) UnreadCount(conversation *Conversation) int64 {
+	unread := conversation.Seq - max(c.ReadSeq, c.ClearedSeq)
+	if c.ReadSeq == 0 && c.Total > 0 {
+		unread = min(unread, int64(conversation.Total-c.Total))
+	}
+	return max(unread, 0)
+}
@@ -0,0 +1,23 @@
+package immodels
+
+import "testing"
+
+func TestConversationUnreadCount(t *testing.T) {
+	conversation := &Conversation{Seq: 10, Total: 10}
+	tests := []struct {
+		name  string
+		entry *Conversation
+		want  int64
+	}{
+		{"never read", &Conversation{}, 10},
+		{"read watermark", &Conversation{ReadSeq: 7, Total: 3}, 3},
+		{"cleared after read", &Conversation{ReadSeq: 4, ClearedSeq: 8}, 2},
+		{"legacy total", &Conversation{Total: 6}, 4},
+		{"ahead of conversation", &Conversation{ReadSeq: 12}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.entry.UnreadCount(conversation); got != tt.want {
+			t.Errorf("%s: UnreadCount() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
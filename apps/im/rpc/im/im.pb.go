@@ -33,7 +33,30 @@ type ChatLog struct {
 	MsgContent     string `protobuf:"bytes,6,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
 	ChatType       int32  `protobuf:"varint,7,opt,name=chatType,proto3" json:"chatType,omitempty"`
 	SendTime       int64  `protobuf:"varint,8,opt,name=SendTime,proto3" json:"SendTime,omitempty"`
-	ReadRecords    []byte `protobuf:"bytes,9,opt,name=readRecords,proto3" json:"readRecords,omitempty"`
+	// 已废弃：旧版本基于哈希位图的已读记录，仅未迁移的旧消息携带
+	ReadRecords []byte `protobuf:"bytes,9,opt,name=readRecords,proto3" json:"readRecords,omitempty"`
+	// 消息在会话内的序号
+	Seq int64 `protobuf:"varint,10,opt,name=seq,proto3" json:"seq,omitempty"`
+	// 消息状态：0 正常，1 已撤回
+	Status int32 `protobuf:"varint,11,opt,name=status,proto3" json:"status,omitempty"`
+	// 最后一次编辑的时间，未编辑过为 0
+	EditedAt int64 `protobuf:"varint,12,opt,name=editedAt,proto3" json:"editedAt,omitempty"`
+	// 编辑前的历史版本，仅在请求时返回
+	Revisions []*ChatLogRevision `protobuf:"bytes,13,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	// 非文本消息的结构化内容
+	Payload *MsgPayload `protobuf:"bytes,14,opt,name=payload,proto3" json:"payload,omitempty"`
+	// 引用回复的消息ID
+	ReplyTo string `protobuf:"bytes,15,opt,name=replyTo,proto3" json:"replyTo,omitempty"`
+	// 引用回复的消息摘要
+	Quote *ChatLogQuote `protobuf:"bytes,16,opt,name=quote,proto3" json:"quote,omitempty"`
+	// 群聊中@的成员ID
+	Mentions []string `protobuf:"bytes,17,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	// 群聊中@所有人
+	MentionAll bool `protobuf:"varint,18,opt,name=mentionAll,proto3" json:"mentionAll,omitempty"`
+	// 表情回应，按回应人数从多到少排列
+	Reactions []*Reaction `protobuf:"bytes,19,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	// 已读人数，不包含发送者
+	ReadCount int64 `protobuf:"varint,20,opt,name=readCount,proto3" json:"readCount,omitempty"`
 }
 
 func (x *ChatLog) Reset() {
@@ -131,42 +154,1538 @@ func (x *ChatLog) GetReadRecords() []byte {
 	return nil
 }
 
+func (x *ChatLog) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChatLog) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ChatLog) GetEditedAt() int64 {
+	if x != nil {
+		return x.EditedAt
+	}
+	return 0
+}
+
+func (x *ChatLog) GetRevisions() []*ChatLogRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+func (x *ChatLog) GetPayload() *MsgPayload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChatLog) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *ChatLog) GetQuote() *ChatLogQuote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *ChatLog) GetMentions() []string {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *ChatLog) GetMentionAll() bool {
+	if x != nil {
+		return x.MentionAll
+	}
+	return false
+}
+
+func (x *ChatLog) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *ChatLog) GetReadCount() int64 {
+	if x != nil {
+		return x.ReadCount
+	}
+	return 0
+}
+
+// 消息的一种表情回应
+type Reaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emoji string `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// 回应的用户ID
+	UserIds []string `protobuf:"bytes,3,rep,name=userIds,proto3" json:"userIds,omitempty"`
+}
+
+func (x *Reaction) Reset() {
+	*x = Reaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reaction) ProtoMessage() {}
+
+func (x *Reaction) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Reaction) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *Reaction) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Reaction) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// 被引用消息的摘要
+type ChatLogQuote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SendId  string `protobuf:"bytes,2,opt,name=sendId,proto3" json:"sendId,omitempty"`
+	MsgType int32  `protobuf:"varint,3,opt,name=msgType,proto3" json:"msgType,omitempty"`
+	// 截断后的消息内容，已撤回的消息为空
+	MsgContent string `protobuf:"bytes,4,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
+	Recalled   bool   `protobuf:"varint,5,opt,name=recalled,proto3" json:"recalled,omitempty"`
+}
+
+func (x *ChatLogQuote) Reset() {
+	*x = ChatLogQuote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatLogQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatLogQuote) ProtoMessage() {}
+
+func (x *ChatLogQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatLogQuote.ProtoReflect.Descriptor instead.
+func (*ChatLogQuote) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChatLogQuote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChatLogQuote) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *ChatLogQuote) GetMsgType() int32 {
+	if x != nil {
+		return x.MsgType
+	}
+	return 0
+}
+
+func (x *ChatLogQuote) GetMsgContent() string {
+	if x != nil {
+		return x.MsgContent
+	}
+	return ""
+}
+
+func (x *ChatLogQuote) GetRecalled() bool {
+	if x != nil {
+		return x.Recalled
+	}
+	return false
+}
+
+// 非文本消息的结构化内容，不同类型的消息使用其中不同的字段
+type MsgPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 图片、文件、语音、视频的资源地址
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// 图片、视频的缩略图地址，引用附件时为缩略图的附件ID
+	Thumb  string `protobuf:"bytes,2,opt,name=thumb,proto3" json:"thumb,omitempty"`
+	Width  int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// 文件名、地点名称或名片名称
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// 资源大小，单位字节
+	Size int64  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	Mime string `protobuf:"bytes,7,opt,name=mime,proto3" json:"mime,omitempty"`
+	// 语音、视频时长，单位秒
+	Duration  int32   `protobuf:"varint,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	Latitude  float64 `protobuf:"fixed64,9,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,10,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Address   string  `protobuf:"bytes,11,opt,name=address,proto3" json:"address,omitempty"`
+	// 名片类型：1 用户，2 群
+	CardType int32 `protobuf:"varint,12,opt,name=cardType,proto3" json:"cardType,omitempty"`
+	// 名片对应的用户ID或群ID
+	TargetId string `protobuf:"bytes,13,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	Avatar   string `protobuf:"bytes,14,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	// 附件ID，通过附件存储上传后得到
+	FileId string `protobuf:"bytes,15,opt,name=fileId,proto3" json:"fileId,omitempty"`
+}
+
+func (x *MsgPayload) Reset() {
+	*x = MsgPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgPayload) ProtoMessage() {}
+
+func (x *MsgPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgPayload.ProtoReflect.Descriptor instead.
+func (*MsgPayload) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MsgPayload) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MsgPayload) GetThumb() string {
+	if x != nil {
+		return x.Thumb
+	}
+	return ""
+}
+
+func (x *MsgPayload) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MsgPayload) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MsgPayload) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MsgPayload) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *MsgPayload) GetMime() string {
+	if x != nil {
+		return x.Mime
+	}
+	return ""
+}
+
+func (x *MsgPayload) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *MsgPayload) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *MsgPayload) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *MsgPayload) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *MsgPayload) GetCardType() int32 {
+	if x != nil {
+		return x.CardType
+	}
+	return 0
+}
+
+func (x *MsgPayload) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *MsgPayload) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *MsgPayload) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type ChatLogRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgContent string `protobuf:"bytes,1,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
+	// 该版本内容的产生时间
+	EditedAt int64 `protobuf:"varint,2,opt,name=editedAt,proto3" json:"editedAt,omitempty"`
+}
+
+func (x *ChatLogRevision) Reset() {
+	*x = ChatLogRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatLogRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatLogRevision) ProtoMessage() {}
+
+func (x *ChatLogRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatLogRevision.ProtoReflect.Descriptor instead.
+func (*ChatLogRevision) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChatLogRevision) GetMsgContent() string {
+	if x != nil {
+		return x.MsgContent
+	}
+	return ""
+}
+
+func (x *ChatLogRevision) GetEditedAt() int64 {
+	if x != nil {
+		return x.EditedAt
+	}
+	return 0
+}
+
 type Conversation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
-	ChatType       int32  `protobuf:"varint,2,opt,name=chatType,proto3" json:"chatType,omitempty"`
-	TargetId       string `protobuf:"bytes,3,opt,name=targetId,proto3" json:"targetId,omitempty"`
-	IsShow         bool   `protobuf:"varint,4,opt,name=isShow,proto3" json:"isShow,omitempty"`
-	Seq            int64  `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
-	// 总消息数
-	Total int32 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
-	// 未读消息数
-	ToRead int32 `protobuf:"varint,7,opt,name=toRead,proto3" json:"toRead,omitempty"`
-	// 已读消息
-	Read int32    `protobuf:"varint,9,opt,name=Read,proto3" json:"Read,omitempty"`
-	Msg  *ChatLog `protobuf:"bytes,8,opt,name=msg,proto3" json:"msg,omitempty"`
+	ChatType       int32  `protobuf:"varint,2,opt,name=chatType,proto3" json:"chatType,omitempty"`
+	TargetId       string `protobuf:"bytes,3,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	IsShow         bool   `protobuf:"varint,4,opt,name=isShow,proto3" json:"isShow,omitempty"`
+	Seq            int64  `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	// 总消息数
+	Total int32 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	// 未读消息数，由服务端根据已读序号计算
+	ToRead int32 `protobuf:"varint,7,opt,name=toRead,proto3" json:"toRead,omitempty"`
+	// 已读消息
+	Read int32    `protobuf:"varint,9,opt,name=Read,proto3" json:"Read,omitempty"`
+	Msg  *ChatLog `protobuf:"bytes,8,opt,name=msg,proto3" json:"msg,omitempty"`
+	// 未读消息中@我（包括@所有人）的消息数
+	Mentioned int32 `protobuf:"varint,10,opt,name=mentioned,proto3" json:"mentioned,omitempty"`
+	// 是否置顶，置顶的会话按 pinOrder 从大到小排列
+	Pinned   bool  `protobuf:"varint,11,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	PinOrder int64 `protobuf:"varint,12,opt,name=pinOrder,proto3" json:"pinOrder,omitempty"`
+	// 免打扰截止时间（毫秒），-1 为永久免打扰，0 为不开启
+	MutedUntil int64 `protobuf:"varint,13,opt,name=mutedUntil,proto3" json:"mutedUntil,omitempty"`
+	// 是否归档
+	Archived bool `protobuf:"varint,14,opt,name=archived,proto3" json:"archived,omitempty"`
+	// 自定义的会话名称
+	DisplayName string `protobuf:"bytes,15,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	// 用户清空聊天记录时会话的最大序号，不超过该序号的消息对用户不可见
+	ClearedSeq int64 `protobuf:"varint,16,opt,name=clearedSeq,proto3" json:"clearedSeq,omitempty"`
+	// 用户已读到的消息序号，未读消息数为 seq - readSeq
+	ReadSeq int64 `protobuf:"varint,17,opt,name=readSeq,proto3" json:"readSeq,omitempty"`
+}
+
+func (x *Conversation) Reset() {
+	*x = Conversation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Conversation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversation) ProtoMessage() {}
+
+func (x *Conversation) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
+func (*Conversation) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Conversation) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *Conversation) GetChatType() int32 {
+	if x != nil {
+		return x.ChatType
+	}
+	return 0
+}
+
+func (x *Conversation) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Conversation) GetIsShow() bool {
+	if x != nil {
+		return x.IsShow
+	}
+	return false
+}
+
+func (x *Conversation) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Conversation) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Conversation) GetToRead() int32 {
+	if x != nil {
+		return x.ToRead
+	}
+	return 0
+}
+
+func (x *Conversation) GetRead() int32 {
+	if x != nil {
+		return x.Read
+	}
+	return 0
+}
+
+func (x *Conversation) GetMsg() *ChatLog {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *Conversation) GetMentioned() int32 {
+	if x != nil {
+		return x.Mentioned
+	}
+	return 0
+}
+
+func (x *Conversation) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Conversation) GetPinOrder() int64 {
+	if x != nil {
+		return x.PinOrder
+	}
+	return 0
+}
+
+func (x *Conversation) GetMutedUntil() int64 {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return 0
+}
+
+func (x *Conversation) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Conversation) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Conversation) GetClearedSeq() int64 {
+	if x != nil {
+		return x.ClearedSeq
+	}
+	return 0
+}
+
+func (x *Conversation) GetReadSeq() int64 {
+	if x != nil {
+		return x.ReadSeq
+	}
+	return 0
+}
+
+type GetConversationsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+}
+
+func (x *GetConversationsReq) Reset() {
+	*x = GetConversationsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConversationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConversationsReq) ProtoMessage() {}
+
+func (x *GetConversationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConversationsReq.ProtoReflect.Descriptor instead.
+func (*GetConversationsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetConversationsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetConversationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationList map[string]*Conversation `protobuf:"bytes,2,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *GetConversationsResp) Reset() {
+	*x = GetConversationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConversationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConversationsResp) ProtoMessage() {}
+
+func (x *GetConversationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConversationsResp.ProtoReflect.Descriptor instead.
+func (*GetConversationsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetConversationsResp) GetConversationList() map[string]*Conversation {
+	if x != nil {
+		return x.ConversationList
+	}
+	return nil
+}
+
+type PutConversationsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                   `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationList map[string]*Conversation `protobuf:"bytes,3,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *PutConversationsReq) Reset() {
+	*x = PutConversationsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutConversationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutConversationsReq) ProtoMessage() {}
+
+func (x *PutConversationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutConversationsReq.ProtoReflect.Descriptor instead.
+func (*PutConversationsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PutConversationsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PutConversationsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PutConversationsReq) GetConversationList() map[string]*Conversation {
+	if x != nil {
+		return x.ConversationList
+	}
+	return nil
+}
+
+type PutConversationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PutConversationsResp) Reset() {
+	*x = PutConversationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutConversationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutConversationsResp) ProtoMessage() {}
+
+func (x *PutConversationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutConversationsResp.ProtoReflect.Descriptor instead.
+func (*PutConversationsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{9}
+}
+
+type ClearConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+}
+
+func (x *ClearConversationReq) Reset() {
+	*x = ClearConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearConversationReq) ProtoMessage() {}
+
+func (x *ClearConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearConversationReq.ProtoReflect.Descriptor instead.
+func (*ClearConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClearConversationReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ClearConversationReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ClearConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearConversationResp) Reset() {
+	*x = ClearConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearConversationResp) ProtoMessage() {}
+
+func (x *ClearConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearConversationResp.ProtoReflect.Descriptor instead.
+func (*ClearConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{11}
+}
+
+type DeleteConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+}
+
+func (x *DeleteConversationReq) Reset() {
+	*x = DeleteConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConversationReq) ProtoMessage() {}
+
+func (x *DeleteConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConversationReq.ProtoReflect.Descriptor instead.
+func (*DeleteConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteConversationReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteConversationReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type DeleteConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteConversationResp) Reset() {
+	*x = DeleteConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConversationResp) ProtoMessage() {}
+
+func (x *DeleteConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConversationResp.ProtoReflect.Descriptor instead.
+func (*DeleteConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{13}
+}
+
+type GetChatLogReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	// 未携带游标时，从该发送时间（包含）开始向前查询
+	StartSendTime int64 `protobuf:"varint,2,opt,name=startSendTime,proto3" json:"startSendTime,omitempty"`
+	// 发送时间下限（不包含）
+	EndSendTime int64 `protobuf:"varint,3,opt,name=endSendTime,proto3" json:"endSendTime,omitempty"`
+	Count       int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// 未指定 direction 时查询单条消息；direction 为 around 时作为定位的消息
+	MsgId string `protobuf:"bytes,5,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	// 是否返回消息的编辑历史
+	WithRevisions bool `protobuf:"varint,6,opt,name=withRevisions,proto3" json:"withRevisions,omitempty"`
+	// 上一页返回的 nextCursor 或 prevCursor
+	Cursor string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 分页方向：0 older 查询更早的消息，1 newer 查询更新的消息，2 around 查询 msgId 前后的消息
+	Direction int32 `protobuf:"varint,8,opt,name=direction,proto3" json:"direction,omitempty"`
+	// 查询的用户，用户清空过聊天记录时只返回清空之后的消息
+	UserId string `protobuf:"bytes,9,opt,name=userId,proto3" json:"userId,omitempty"`
+}
+
+func (x *GetChatLogReq) Reset() {
+	*x = GetChatLogReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChatLogReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatLogReq) ProtoMessage() {}
+
+func (x *GetChatLogReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatLogReq.ProtoReflect.Descriptor instead.
+func (*GetChatLogReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetChatLogReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetStartSendTime() int64 {
+	if x != nil {
+		return x.StartSendTime
+	}
+	return 0
+}
+
+func (x *GetChatLogReq) GetEndSendTime() int64 {
+	if x != nil {
+		return x.EndSendTime
+	}
+	return 0
+}
+
+func (x *GetChatLogReq) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetChatLogReq) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetWithRevisions() bool {
+	if x != nil {
+		return x.WithRevisions
+	}
+	return false
+}
+
+func (x *GetChatLogReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetDirection() int32 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+func (x *GetChatLogReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetChatLogResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 按发送时间从新到旧排列
+	List []*ChatLog `protobuf:"bytes,1,rep,name=List,proto3" json:"List,omitempty"`
+	// 继续查询更早消息的游标，没有更早的消息时为空
+	NextCursor string `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	// 继续查询更新消息的游标，没有更新的消息时为空
+	PrevCursor string `protobuf:"bytes,3,opt,name=prevCursor,proto3" json:"prevCursor,omitempty"`
+	// 请求的方向上是否还有更多消息
+	HasMore bool `protobuf:"varint,4,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+}
+
+func (x *GetChatLogResp) Reset() {
+	*x = GetChatLogResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChatLogResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatLogResp) ProtoMessage() {}
+
+func (x *GetChatLogResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatLogResp.ProtoReflect.Descriptor instead.
+func (*GetChatLogResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetChatLogResp) GetList() []*ChatLog {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *GetChatLogResp) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetChatLogResp) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *GetChatLogResp) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type SyncMessagesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	// 客户端已收到的最大序号，返回该序号之后的消息
+	FromSeq int64 `protobuf:"varint,2,opt,name=fromSeq,proto3" json:"fromSeq,omitempty"`
+	Limit   int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 同步的用户，用户清空过聊天记录时只返回清空之后的消息
+	UserId string `protobuf:"bytes,4,opt,name=userId,proto3" json:"userId,omitempty"`
+}
+
+func (x *SyncMessagesReq) Reset() {
+	*x = SyncMessagesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncMessagesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncMessagesReq) ProtoMessage() {}
+
+func (x *SyncMessagesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncMessagesReq.ProtoReflect.Descriptor instead.
+func (*SyncMessagesReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SyncMessagesReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SyncMessagesReq) GetFromSeq() int64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *SyncMessagesReq) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SyncMessagesReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SyncMessagesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List []*ChatLog `protobuf:"bytes,1,rep,name=List,proto3" json:"List,omitempty"`
+	// 是否还有未拉取的消息
+	HasMore bool `protobuf:"varint,2,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+}
+
+func (x *SyncMessagesResp) Reset() {
+	*x = SyncMessagesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncMessagesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncMessagesResp) ProtoMessage() {}
+
+func (x *SyncMessagesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncMessagesResp.ProtoReflect.Descriptor instead.
+func (*SyncMessagesResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SyncMessagesResp) GetList() []*ChatLog {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *SyncMessagesResp) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type SearchChatLogReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId  string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Keyword string `protobuf:"bytes,2,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	// 以下为可选的过滤条件
+	ConversationId string  `protobuf:"bytes,3,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	SendId         string  `protobuf:"bytes,4,opt,name=sendId,proto3" json:"sendId,omitempty"`
+	StartTime      int64   `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime        int64   `protobuf:"varint,6,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	MsgTypes       []int32 `protobuf:"varint,7,rep,packed,name=msgTypes,proto3" json:"msgTypes,omitempty"`
+	// 上一页返回的游标，首页为空
+	Cursor string `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit  int64  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SearchChatLogReq) Reset() {
+	*x = SearchChatLogReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchChatLogReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchChatLogReq) ProtoMessage() {}
+
+func (x *SearchChatLogReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchChatLogReq.ProtoReflect.Descriptor instead.
+func (*SearchChatLogReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchChatLogReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchChatLogReq) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *SearchChatLogReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SearchChatLogReq) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *SearchChatLogReq) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *SearchChatLogReq) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *SearchChatLogReq) GetMsgTypes() []int32 {
+	if x != nil {
+		return x.MsgTypes
+	}
+	return nil
+}
+
+func (x *SearchChatLogReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchChatLogReq) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 高亮区间，为摘要中以字符计的左闭右开区间
+type Highlight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Highlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Highlight) ProtoMessage() {}
+
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Highlight) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Highlight) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type SearchChatLogHit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChatLog *ChatLog `protobuf:"bytes,1,opt,name=chatLog,proto3" json:"chatLog,omitempty"`
+	// 命中关键词附近的内容摘要
+	Snippet    string       `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Highlights []*Highlight `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
 }
 
-func (x *Conversation) Reset() {
-	*x = Conversation{}
+func (x *SearchChatLogHit) Reset() {
+	*x = SearchChatLogHit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[1]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Conversation) String() string {
+func (x *SearchChatLogHit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Conversation) ProtoMessage() {}
+func (*SearchChatLogHit) ProtoMessage() {}
 
-func (x *Conversation) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[1]
+func (x *SearchChatLogHit) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,99 +1696,120 @@ func (x *Conversation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
-func (*Conversation) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SearchChatLogHit.ProtoReflect.Descriptor instead.
+func (*SearchChatLogHit) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *Conversation) GetConversationId() string {
+func (x *SearchChatLogHit) GetChatLog() *ChatLog {
 	if x != nil {
-		return x.ConversationId
+		return x.ChatLog
 	}
-	return ""
+	return nil
 }
 
-func (x *Conversation) GetChatType() int32 {
+func (x *SearchChatLogHit) GetSnippet() string {
 	if x != nil {
-		return x.ChatType
+		return x.Snippet
 	}
-	return 0
+	return ""
 }
 
-func (x *Conversation) GetTargetId() string {
+func (x *SearchChatLogHit) GetHighlights() []*Highlight {
 	if x != nil {
-		return x.TargetId
+		return x.Highlights
 	}
-	return ""
+	return nil
 }
 
-func (x *Conversation) GetIsShow() bool {
-	if x != nil {
-		return x.IsShow
-	}
-	return false
+type SearchChatLogResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List       []*SearchChatLogHit `protobuf:"bytes,1,rep,name=List,proto3" json:"List,omitempty"`
+	NextCursor string              `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	HasMore    bool                `protobuf:"varint,3,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
 }
 
-func (x *Conversation) GetSeq() int64 {
-	if x != nil {
-		return x.Seq
+func (x *SearchChatLogResp) Reset() {
+	*x = SearchChatLogResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Conversation) GetTotal() int32 {
-	if x != nil {
-		return x.Total
+func (x *SearchChatLogResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchChatLogResp) ProtoMessage() {}
+
+func (x *SearchChatLogResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Conversation) GetToRead() int32 {
+// Deprecated: Use SearchChatLogResp.ProtoReflect.Descriptor instead.
+func (*SearchChatLogResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchChatLogResp) GetList() []*SearchChatLogHit {
 	if x != nil {
-		return x.ToRead
+		return x.List
 	}
-	return 0
+	return nil
 }
 
-func (x *Conversation) GetRead() int32 {
+func (x *SearchChatLogResp) GetNextCursor() string {
 	if x != nil {
-		return x.Read
+		return x.NextCursor
 	}
-	return 0
+	return ""
 }
 
-func (x *Conversation) GetMsg() *ChatLog {
+func (x *SearchChatLogResp) GetHasMore() bool {
 	if x != nil {
-		return x.Msg
+		return x.HasMore
 	}
-	return nil
+	return false
 }
 
-type GetConversationsReq struct {
+type GetReadRecordsReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	MsgId string `protobuf:"bytes,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
 }
 
-func (x *GetConversationsReq) Reset() {
-	*x = GetConversationsReq{}
+func (x *GetReadRecordsReq) Reset() {
+	*x = GetReadRecordsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[2]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetConversationsReq) String() string {
+func (x *GetReadRecordsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConversationsReq) ProtoMessage() {}
+func (*GetReadRecordsReq) ProtoMessage() {}
 
-func (x *GetConversationsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[2]
+func (x *GetReadRecordsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,43 +1820,45 @@ func (x *GetConversationsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConversationsReq.ProtoReflect.Descriptor instead.
-func (*GetConversationsReq) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetReadRecordsReq.ProtoReflect.Descriptor instead.
+func (*GetReadRecordsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GetConversationsReq) GetUserId() string {
+func (x *GetReadRecordsReq) GetMsgId() string {
 	if x != nil {
-		return x.UserId
+		return x.MsgId
 	}
 	return ""
 }
 
-type GetConversationsResp struct {
+type GetReadRecordsResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConversationList map[string]*Conversation `protobuf:"bytes,2,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ChatLog *ChatLog `protobuf:"bytes,1,opt,name=chatLog,proto3" json:"chatLog,omitempty"`
+	// 已读该消息的用户ID，不包含发送者
+	ReadBy []string `protobuf:"bytes,2,rep,name=readBy,proto3" json:"readBy,omitempty"`
 }
 
-func (x *GetConversationsResp) Reset() {
-	*x = GetConversationsResp{}
+func (x *GetReadRecordsResp) Reset() {
+	*x = GetReadRecordsResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[3]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetConversationsResp) String() string {
+func (x *GetReadRecordsResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConversationsResp) ProtoMessage() {}
+func (*GetReadRecordsResp) ProtoMessage() {}
 
-func (x *GetConversationsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[3]
+func (x *GetReadRecordsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,45 +1869,52 @@ func (x *GetConversationsResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConversationsResp.ProtoReflect.Descriptor instead.
-func (*GetConversationsResp) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetReadRecordsResp.ProtoReflect.Descriptor instead.
+func (*GetReadRecordsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *GetConversationsResp) GetConversationList() map[string]*Conversation {
+func (x *GetReadRecordsResp) GetChatLog() *ChatLog {
 	if x != nil {
-		return x.ConversationList
+		return x.ChatLog
 	}
 	return nil
 }
 
-type PutConversationsReq struct {
+func (x *GetReadRecordsResp) GetReadBy() []string {
+	if x != nil {
+		return x.ReadBy
+	}
+	return nil
+}
+
+type RecallMsgReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id               string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId           string                   `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
-	ConversationList map[string]*Conversation `protobuf:"bytes,3,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MsgId string `protobuf:"bytes,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	// 执行撤回的用户
+	UserId string `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
 }
 
-func (x *PutConversationsReq) Reset() {
-	*x = PutConversationsReq{}
+func (x *RecallMsgReq) Reset() {
+	*x = RecallMsgReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[4]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PutConversationsReq) String() string {
+func (x *RecallMsgReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutConversationsReq) ProtoMessage() {}
+func (*RecallMsgReq) ProtoMessage() {}
 
-func (x *PutConversationsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[4]
+func (x *RecallMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -376,55 +1925,48 @@ func (x *PutConversationsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutConversationsReq.ProtoReflect.Descriptor instead.
-func (*PutConversationsReq) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use RecallMsgReq.ProtoReflect.Descriptor instead.
+func (*RecallMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *PutConversationsReq) GetId() string {
+func (x *RecallMsgReq) GetMsgId() string {
 	if x != nil {
-		return x.Id
+		return x.MsgId
 	}
 	return ""
 }
 
-func (x *PutConversationsReq) GetUserId() string {
+func (x *RecallMsgReq) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *PutConversationsReq) GetConversationList() map[string]*Conversation {
-	if x != nil {
-		return x.ConversationList
-	}
-	return nil
-}
-
-type PutConversationsResp struct {
+type RecallMsgResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *PutConversationsResp) Reset() {
-	*x = PutConversationsResp{}
+func (x *RecallMsgResp) Reset() {
+	*x = RecallMsgResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[5]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PutConversationsResp) String() string {
+func (x *RecallMsgResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutConversationsResp) ProtoMessage() {}
+func (*RecallMsgResp) ProtoMessage() {}
 
-func (x *PutConversationsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[5]
+func (x *RecallMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,40 +1977,39 @@ func (x *PutConversationsResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutConversationsResp.ProtoReflect.Descriptor instead.
-func (*PutConversationsResp) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use RecallMsgResp.ProtoReflect.Descriptor instead.
+func (*RecallMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{25}
 }
 
-type GetChatLogReq struct {
+type EditMsgReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
-	StartSendTime  int64  `protobuf:"varint,2,opt,name=startSendTime,proto3" json:"startSendTime,omitempty"`
-	EndSendTime    int64  `protobuf:"varint,3,opt,name=endSendTime,proto3" json:"endSendTime,omitempty"`
-	Count          int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
-	MsgId          string `protobuf:"bytes,5,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	MsgId string `protobuf:"bytes,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	// 执行编辑的用户
+	UserId     string `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	MsgContent string `protobuf:"bytes,3,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
 }
 
-func (x *GetChatLogReq) Reset() {
-	*x = GetChatLogReq{}
+func (x *EditMsgReq) Reset() {
+	*x = EditMsgReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[6]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetChatLogReq) String() string {
+func (x *EditMsgReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatLogReq) ProtoMessage() {}
+func (*EditMsgReq) ProtoMessage() {}
 
-func (x *GetChatLogReq) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[6]
+func (x *EditMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -479,71 +2020,178 @@ func (x *GetChatLogReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatLogReq.ProtoReflect.Descriptor instead.
-func (*GetChatLogReq) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use EditMsgReq.ProtoReflect.Descriptor instead.
+func (*EditMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetChatLogReq) GetConversationId() string {
+func (x *EditMsgReq) GetMsgId() string {
 	if x != nil {
-		return x.ConversationId
+		return x.MsgId
 	}
 	return ""
 }
 
-func (x *GetChatLogReq) GetStartSendTime() int64 {
+func (x *EditMsgReq) GetUserId() string {
 	if x != nil {
-		return x.StartSendTime
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetChatLogReq) GetEndSendTime() int64 {
+func (x *EditMsgReq) GetMsgContent() string {
 	if x != nil {
-		return x.EndSendTime
+		return x.MsgContent
 	}
-	return 0
+	return ""
 }
 
-func (x *GetChatLogReq) GetCount() int64 {
+type EditMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EditedAt int64 `protobuf:"varint,1,opt,name=editedAt,proto3" json:"editedAt,omitempty"`
+}
+
+func (x *EditMsgResp) Reset() {
+	*x = EditMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMsgResp) ProtoMessage() {}
+
+func (x *EditMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMsgResp.ProtoReflect.Descriptor instead.
+func (*EditMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EditMsgResp) GetEditedAt() int64 {
 	if x != nil {
-		return x.Count
+		return x.EditedAt
 	}
 	return 0
 }
 
-func (x *GetChatLogReq) GetMsgId() string {
+type ReactMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgId string `protobuf:"bytes,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	// 执行回应的用户
+	UserId string `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Emoji  string `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	// 为 true 时移除回应
+	Remove bool `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (x *ReactMsgReq) Reset() {
+	*x = ReactMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactMsgReq) ProtoMessage() {}
+
+func (x *ReactMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactMsgReq.ProtoReflect.Descriptor instead.
+func (*ReactMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReactMsgReq) GetMsgId() string {
 	if x != nil {
 		return x.MsgId
 	}
 	return ""
 }
 
-type GetChatLogResp struct {
+func (x *ReactMsgReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactMsgReq) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactMsgReq) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type ReactMsgResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	List []*ChatLog `protobuf:"bytes,1,rep,name=List,proto3" json:"List,omitempty"`
+	// 更新后的全部表情回应
+	Reactions []*Reaction `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
 }
 
-func (x *GetChatLogResp) Reset() {
-	*x = GetChatLogResp{}
+func (x *ReactMsgResp) Reset() {
+	*x = ReactMsgResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[7]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetChatLogResp) String() string {
+func (x *ReactMsgResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatLogResp) ProtoMessage() {}
+func (*ReactMsgResp) ProtoMessage() {}
 
-func (x *GetChatLogResp) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[7]
+func (x *ReactMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,14 +2202,14 @@ func (x *GetChatLogResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatLogResp.ProtoReflect.Descriptor instead.
-func (*GetChatLogResp) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *GetChatLogResp) GetList() []*ChatLog {
+// Deprecated: Use ReactMsgResp.ProtoReflect.Descriptor instead.
+func (*ReactMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReactMsgResp) GetReactions() []*Reaction {
 	if x != nil {
-		return x.List
+		return x.Reactions
 	}
 	return nil
 }
@@ -579,7 +2227,7 @@ type SetUpUserConversationReq struct {
 func (x *SetUpUserConversationReq) Reset() {
 	*x = SetUpUserConversationReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[8]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -592,7 +2240,7 @@ func (x *SetUpUserConversationReq) String() string {
 func (*SetUpUserConversationReq) ProtoMessage() {}
 
 func (x *SetUpUserConversationReq) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[8]
+	mi := &file_apps_im_rpc_im_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,7 +2253,7 @@ func (x *SetUpUserConversationReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetUpUserConversationReq.ProtoReflect.Descriptor instead.
 func (*SetUpUserConversationReq) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{8}
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SetUpUserConversationReq) GetSendId() string {
@@ -638,7 +2286,7 @@ type SetUpUserConversationResp struct {
 func (x *SetUpUserConversationResp) Reset() {
 	*x = SetUpUserConversationResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[9]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -651,7 +2299,7 @@ func (x *SetUpUserConversationResp) String() string {
 func (*SetUpUserConversationResp) ProtoMessage() {}
 
 func (x *SetUpUserConversationResp) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[9]
+	mi := &file_apps_im_rpc_im_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,7 +2312,7 @@ func (x *SetUpUserConversationResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetUpUserConversationResp.ProtoReflect.Descriptor instead.
 func (*SetUpUserConversationResp) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{9}
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{31}
 }
 
 type CreateGroupConversationReq struct {
@@ -679,7 +2327,7 @@ type CreateGroupConversationReq struct {
 func (x *CreateGroupConversationReq) Reset() {
 	*x = CreateGroupConversationReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[10]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -692,7 +2340,7 @@ func (x *CreateGroupConversationReq) String() string {
 func (*CreateGroupConversationReq) ProtoMessage() {}
 
 func (x *CreateGroupConversationReq) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[10]
+	mi := &file_apps_im_rpc_im_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,7 +2353,7 @@ func (x *CreateGroupConversationReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupConversationReq.ProtoReflect.Descriptor instead.
 func (*CreateGroupConversationReq) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{10}
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CreateGroupConversationReq) GetGroupId() string {
@@ -731,7 +2379,7 @@ type CreateGroupConversationResp struct {
 func (x *CreateGroupConversationResp) Reset() {
 	*x = CreateGroupConversationResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_apps_im_rpc_im_proto_msgTypes[11]
+		mi := &file_apps_im_rpc_im_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -744,7 +2392,7 @@ func (x *CreateGroupConversationResp) String() string {
 func (*CreateGroupConversationResp) ProtoMessage() {}
 
 func (x *CreateGroupConversationResp) ProtoReflect() protoreflect.Message {
-	mi := &file_apps_im_rpc_im_proto_msgTypes[11]
+	mi := &file_apps_im_rpc_im_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,14 +2405,14 @@ func (x *CreateGroupConversationResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupConversationResp.ProtoReflect.Descriptor instead.
 func (*CreateGroupConversationResp) Descriptor() ([]byte, []int) {
-	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{11}
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{33}
 }
 
 var File_apps_im_rpc_im_proto protoreflect.FileDescriptor
 
 var file_apps_im_rpc_im_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x69, 0x6d, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x69, 0x6d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x69, 0x6d, 0x22, 0x85, 0x02, 0x0a, 0x07, 0x43,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x69, 0x6d, 0x22, 0xf0, 0x04, 0x0a, 0x07, 0x43,
 	0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
 	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
@@ -781,30 +2429,125 @@ var file_apps_im_rpc_im_proto_rawDesc = []byte{
 	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
 	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18,
 	0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x22, 0xf9, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63,
-	0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63,
-	0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x53, 0x68, 0x6f, 0x77, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x53, 0x68, 0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x73,
-	0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x52,
-	0x65, 0x61, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x52, 0x65, 0x61, 0x64, 0x12,
-	0x1d, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69,
-	0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x2d,
-	0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc9, 0x01,
-	0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
-	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x2e, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65,
+	0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x73, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6d,
+	0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x07, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69,
+	0x6d, 0x2e, 0x4d, 0x73, 0x67, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x12,
+	0x26, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6c,
+	0x6c, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
+	0x41, 0x6c, 0x6c, 0x12, 0x2a, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x50, 0x0a,
+	0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f,
+	0x6a, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22,
+	0x8c, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x22, 0xf6,
+	0x02, 0x0a, 0x0a, 0x4d, 0x73, 0x67, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x68, 0x75, 0x6d, 0x62, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c,
+	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x61, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x63, 0x61, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61,
+	0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x74, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x73,
+	0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x64,
+	0x69, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x64,
+	0x69, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xe3, 0x03, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x53, 0x68, 0x6f,
+	0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x53, 0x68, 0x6f, 0x77, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65,
+	0x71, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x61,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x52, 0x65, 0x61, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x52,
+	0x65, 0x61, 0x64, 0x12, 0x1d, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6d,
+	0x73, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x69, 0x6e, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x69, 0x6e, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x55, 0x6e, 0x74,
+	0x69, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x55,
+	0x6e, 0x74, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x65, 0x64, 0x53, 0x65, 0x71,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x65, 0x64, 0x53,
+	0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x22, 0x2d, 0x0a, 0x13,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc9, 0x01, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x5a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10,
+	0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74,
+	0x1a, 0x55, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6d, 0x2e,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xef, 0x01, 0x0a, 0x13, 0x50, 0x75, 0x74, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x59, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65,
 	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
 	0x52, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
 	0x73, 0x74, 0x1a, 0x55, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
@@ -812,78 +2555,201 @@ var file_apps_im_rpc_im_proto_rawDesc = []byte{
 	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a,
 	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69,
 	0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xef, 0x01, 0x0a, 0x13, 0x50, 0x75,
-	0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x59, 0x0a, 0x10, 0x63, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x2e, 0x43, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x4c, 0x69, 0x73, 0x74, 0x1a, 0x55, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x50,
-	0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x22, 0xab, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c,
-	0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
-	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x24, 0x0a,
-	0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69,
-	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x6e,
-	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d,
-	0x73, 0x67, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49,
-	0x64, 0x22, 0x31, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52,
-	0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x04,
-	0x4c, 0x69, 0x73, 0x74, 0x22, 0x66, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76,
-	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x1b, 0x0a, 0x19,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x50, 0x75, 0x74,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x56, 0x0a, 0x14, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x57, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x18, 0x0a, 0x16, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x9f, 0x02, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61,
+	0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x6e,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65, 0x6e, 0x64, 0x53,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73,
+	0x67, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x77, 0x69, 0x74, 0x68, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x77, 0x69, 0x74, 0x68,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x8b, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43,
+	0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a, 0x04, 0x4c, 0x69,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68,
+	0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e,
+	0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
+	0x72, 0x65, 0x76, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x72, 0x65, 0x76, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x68,
+	0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61,
+	0x73, 0x4d, 0x6f, 0x72, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x0f, 0x53, 0x79, 0x6e, 0x63, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x10, 0x53, 0x79, 0x6e,
+	0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a,
+	0x04, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d,
+	0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x22, 0x86, 0x02, 0x0a, 0x10, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x12,
+	0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x73, 0x67, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x73, 0x67, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0x33, 0x0a, 0x09, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x82, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x48, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69,
+	0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x07, 0x63, 0x68, 0x61, 0x74, 0x4c,
+	0x6f, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x0a,
+	0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x69, 0x6d, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x52,
+	0x0a, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0x77, 0x0a, 0x11, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x28, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
+	0x67, 0x48, 0x69, 0x74, 0x52, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65,
+	0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61,
+	0x73, 0x4d, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73,
+	0x4d, 0x6f, 0x72, 0x65, 0x22, 0x29, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x22,
+	0x53, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x25, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x07, 0x63, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x64, 0x42, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x64, 0x42, 0x79, 0x22, 0x3c, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73,
+	0x67, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x5a, 0x0a, 0x0a, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x71, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22,
+	0x29, 0x0a, 0x0b, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x69, 0x0a, 0x0b, 0x52, 0x65,
+	0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x73,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x66, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a,
+	0x06, 0x53, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53,
+	0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x53, 0x65, 0x74,
+	0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x52, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x32, 0xd7, 0x06, 0x0a, 0x02, 0x49, 0x6d,
+	0x12, 0x33, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x11,
+	0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x71, 0x1a, 0x12, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x39, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x13, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x2e,
+	0x53, 0x79, 0x6e, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x3c, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
+	0x67, 0x12, 0x14, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x68, 0x61,
+	0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3f,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x12, 0x15, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x30, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67, 0x12, 0x10, 0x2e, 0x69,
+	0x6d, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x11,
+	0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x2a, 0x0a, 0x07, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x2e, 0x69,
+	0x6d, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x69,
+	0x6d, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2d, 0x0a,
+	0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x0f, 0x2e, 0x69, 0x6d, 0x2e, 0x52,
+	0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x69, 0x6d, 0x2e,
+	0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x54, 0x0a, 0x15,
 	0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x52, 0x0a, 0x1a, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x64, 0x22, 0x1d, 0x0a,
-	0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x32, 0xf9, 0x02, 0x0a,
-	0x02, 0x49, 0x6d, 0x12, 0x33, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
-	0x67, 0x12, 0x11, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61,
-	0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x54, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x55,
-	0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x1c, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x45, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45, 0x0a, 0x10, 0x50, 0x75, 0x74,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e,
+	0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x48, 0x0a, 0x11, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72,
 	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a,
-	0x1d, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45,
-	0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x69, 0x6d,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45, 0x0a, 0x10, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x50,
-	0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x71, 0x1a, 0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5a, 0x0a, 0x17,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1f, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x69, 0x6d,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x19, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4b, 0x0a, 0x12, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x19, 0x2e, 0x69, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x69, 0x6d,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5a, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x1a, 0x1f, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x69, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -898,45 +2764,93 @@ func file_apps_im_rpc_im_proto_rawDescGZIP() []byte {
 	return file_apps_im_rpc_im_proto_rawDescData
 }
 
-var file_apps_im_rpc_im_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_apps_im_rpc_im_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_apps_im_rpc_im_proto_goTypes = []interface{}{
 	(*ChatLog)(nil),                     // 0: im.ChatLog
-	(*Conversation)(nil),                // 1: im.Conversation
-	(*GetConversationsReq)(nil),         // 2: im.GetConversationsReq
-	(*GetConversationsResp)(nil),        // 3: im.GetConversationsResp
-	(*PutConversationsReq)(nil),         // 4: im.PutConversationsReq
-	(*PutConversationsResp)(nil),        // 5: im.PutConversationsResp
-	(*GetChatLogReq)(nil),               // 6: im.GetChatLogReq
-	(*GetChatLogResp)(nil),              // 7: im.GetChatLogResp
-	(*SetUpUserConversationReq)(nil),    // 8: im.SetUpUserConversationReq
-	(*SetUpUserConversationResp)(nil),   // 9: im.SetUpUserConversationResp
-	(*CreateGroupConversationReq)(nil),  // 10: im.CreateGroupConversationReq
-	(*CreateGroupConversationResp)(nil), // 11: im.CreateGroupConversationResp
-	nil,                                 // 12: im.GetConversationsResp.ConversationListEntry
-	nil,                                 // 13: im.PutConversationsReq.ConversationListEntry
+	(*Reaction)(nil),                    // 1: im.Reaction
+	(*ChatLogQuote)(nil),                // 2: im.ChatLogQuote
+	(*MsgPayload)(nil),                  // 3: im.MsgPayload
+	(*ChatLogRevision)(nil),             // 4: im.ChatLogRevision
+	(*Conversation)(nil),                // 5: im.Conversation
+	(*GetConversationsReq)(nil),         // 6: im.GetConversationsReq
+	(*GetConversationsResp)(nil),        // 7: im.GetConversationsResp
+	(*PutConversationsReq)(nil),         // 8: im.PutConversationsReq
+	(*PutConversationsResp)(nil),        // 9: im.PutConversationsResp
+	(*ClearConversationReq)(nil),        // 10: im.ClearConversationReq
+	(*ClearConversationResp)(nil),       // 11: im.ClearConversationResp
+	(*DeleteConversationReq)(nil),       // 12: im.DeleteConversationReq
+	(*DeleteConversationResp)(nil),      // 13: im.DeleteConversationResp
+	(*GetChatLogReq)(nil),               // 14: im.GetChatLogReq
+	(*GetChatLogResp)(nil),              // 15: im.GetChatLogResp
+	(*SyncMessagesReq)(nil),             // 16: im.SyncMessagesReq
+	(*SyncMessagesResp)(nil),            // 17: im.SyncMessagesResp
+	(*SearchChatLogReq)(nil),            // 18: im.SearchChatLogReq
+	(*Highlight)(nil),                   // 19: im.Highlight
+	(*SearchChatLogHit)(nil),            // 20: im.SearchChatLogHit
+	(*SearchChatLogResp)(nil),           // 21: im.SearchChatLogResp
+	(*GetReadRecordsReq)(nil),           // 22: im.GetReadRecordsReq
+	(*GetReadRecordsResp)(nil),          // 23: im.GetReadRecordsResp
+	(*RecallMsgReq)(nil),                // 24: im.RecallMsgReq
+	(*RecallMsgResp)(nil),               // 25: im.RecallMsgResp
+	(*EditMsgReq)(nil),                  // 26: im.EditMsgReq
+	(*EditMsgResp)(nil),                 // 27: im.EditMsgResp
+	(*ReactMsgReq)(nil),                 // 28: im.ReactMsgReq
+	(*ReactMsgResp)(nil),                // 29: im.ReactMsgResp
+	(*SetUpUserConversationReq)(nil),    // 30: im.SetUpUserConversationReq
+	(*SetUpUserConversationResp)(nil),   // 31: im.SetUpUserConversationResp
+	(*CreateGroupConversationReq)(nil),  // 32: im.CreateGroupConversationReq
+	(*CreateGroupConversationResp)(nil), // 33: im.CreateGroupConversationResp
+	nil,                                 // 34: im.GetConversationsResp.ConversationListEntry
+	nil,                                 // 35: im.PutConversationsReq.ConversationListEntry
 }
 var file_apps_im_rpc_im_proto_depIdxs = []int32{
-	0,  // 0: im.Conversation.msg:type_name -> im.ChatLog
-	12, // 1: im.GetConversationsResp.conversationList:type_name -> im.GetConversationsResp.ConversationListEntry
-	13, // 2: im.PutConversationsReq.conversationList:type_name -> im.PutConversationsReq.ConversationListEntry
-	0,  // 3: im.GetChatLogResp.List:type_name -> im.ChatLog
-	1,  // 4: im.GetConversationsResp.ConversationListEntry.value:type_name -> im.Conversation
-	1,  // 5: im.PutConversationsReq.ConversationListEntry.value:type_name -> im.Conversation
-	6,  // 6: im.Im.GetChatLog:input_type -> im.GetChatLogReq
-	8,  // 7: im.Im.SetUpUserConversation:input_type -> im.SetUpUserConversationReq
-	2,  // 8: im.Im.GetConversations:input_type -> im.GetConversationsReq
-	4,  // 9: im.Im.PutConversations:input_type -> im.PutConversationsReq
-	10, // 10: im.Im.CreateGroupConversation:input_type -> im.CreateGroupConversationReq
-	7,  // 11: im.Im.GetChatLog:output_type -> im.GetChatLogResp
-	9,  // 12: im.Im.SetUpUserConversation:output_type -> im.SetUpUserConversationResp
-	3,  // 13: im.Im.GetConversations:output_type -> im.GetConversationsResp
-	5,  // 14: im.Im.PutConversations:output_type -> im.PutConversationsResp
-	11, // 15: im.Im.CreateGroupConversation:output_type -> im.CreateGroupConversationResp
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	4,  // 0: im.ChatLog.revisions:type_name -> im.ChatLogRevision
+	3,  // 1: im.ChatLog.payload:type_name -> im.MsgPayload
+	2,  // 2: im.ChatLog.quote:type_name -> im.ChatLogQuote
+	1,  // 3: im.ChatLog.reactions:type_name -> im.Reaction
+	0,  // 4: im.Conversation.msg:type_name -> im.ChatLog
+	34, // 5: im.GetConversationsResp.conversationList:type_name -> im.GetConversationsResp.ConversationListEntry
+	35, // 6: im.PutConversationsReq.conversationList:type_name -> im.PutConversationsReq.ConversationListEntry
+	0,  // 7: im.GetChatLogResp.List:type_name -> im.ChatLog
+	0,  // 8: im.SyncMessagesResp.List:type_name -> im.ChatLog
+	0,  // 9: im.SearchChatLogHit.chatLog:type_name -> im.ChatLog
+	19, // 10: im.SearchChatLogHit.highlights:type_name -> im.Highlight
+	20, // 11: im.SearchChatLogResp.List:type_name -> im.SearchChatLogHit
+	0,  // 12: im.GetReadRecordsResp.chatLog:type_name -> im.ChatLog
+	1,  // 13: im.ReactMsgResp.reactions:type_name -> im.Reaction
+	5,  // 14: im.GetConversationsResp.ConversationListEntry.value:type_name -> im.Conversation
+	5,  // 15: im.PutConversationsReq.ConversationListEntry.value:type_name -> im.Conversation
+	14, // 16: im.Im.GetChatLog:input_type -> im.GetChatLogReq
+	16, // 17: im.Im.SyncMessages:input_type -> im.SyncMessagesReq
+	18, // 18: im.Im.SearchChatLog:input_type -> im.SearchChatLogReq
+	22, // 19: im.Im.GetReadRecords:input_type -> im.GetReadRecordsReq
+	24, // 20: im.Im.RecallMsg:input_type -> im.RecallMsgReq
+	26, // 21: im.Im.EditMsg:input_type -> im.EditMsgReq
+	28, // 22: im.Im.ReactMsg:input_type -> im.ReactMsgReq
+	30, // 23: im.Im.SetUpUserConversation:input_type -> im.SetUpUserConversationReq
+	6,  // 24: im.Im.GetConversations:input_type -> im.GetConversationsReq
+	8,  // 25: im.Im.PutConversations:input_type -> im.PutConversationsReq
+	10, // 26: im.Im.ClearConversation:input_type -> im.ClearConversationReq
+	12, // 27: im.Im.DeleteConversation:input_type -> im.DeleteConversationReq
+	32, // 28: im.Im.CreateGroupConversation:input_type -> im.CreateGroupConversationReq
+	15, // 29: im.Im.GetChatLog:output_type -> im.GetChatLogResp
+	17, // 30: im.Im.SyncMessages:output_type -> im.SyncMessagesResp
+	21, // 31: im.Im.SearchChatLog:output_type -> im.SearchChatLogResp
+	23, // 32: im.Im.GetReadRecords:output_type -> im.GetReadRecordsResp
+	25, // 33: im.Im.RecallMsg:output_type -> im.RecallMsgResp
+	27, // 34: im.Im.EditMsg:output_type -> im.EditMsgResp
+	29, // 35: im.Im.ReactMsg:output_type -> im.ReactMsgResp
+	31, // 36: im.Im.SetUpUserConversation:output_type -> im.SetUpUserConversationResp
+	7,  // 37: im.Im.GetConversations:output_type -> im.GetConversationsResp
+	9,  // 38: im.Im.PutConversations:output_type -> im.PutConversationsResp
+	11, // 39: im.Im.ClearConversation:output_type -> im.ClearConversationResp
+	13, // 40: im.Im.DeleteConversation:output_type -> im.DeleteConversationResp
+	33, // 41: im.Im.CreateGroupConversation:output_type -> im.CreateGroupConversationResp
+	29, // [29:42] is the sub-list for method output_type
+	16, // [16:29] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_apps_im_rpc_im_proto_init() }
@@ -958,7 +2872,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Conversation); i {
+			switch v := v.(*Reaction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -970,7 +2884,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetConversationsReq); i {
+			switch v := v.(*ChatLogQuote); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -982,7 +2896,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetConversationsResp); i {
+			switch v := v.(*MsgPayload); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -994,7 +2908,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutConversationsReq); i {
+			switch v := v.(*ChatLogRevision); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1006,7 +2920,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutConversationsResp); i {
+			switch v := v.(*Conversation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1018,7 +2932,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetChatLogReq); i {
+			switch v := v.(*GetConversationsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1030,7 +2944,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetChatLogResp); i {
+			switch v := v.(*GetConversationsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1042,7 +2956,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetUpUserConversationReq); i {
+			switch v := v.(*PutConversationsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1054,7 +2968,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetUpUserConversationResp); i {
+			switch v := v.(*PutConversationsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1066,7 +2980,7 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateGroupConversationReq); i {
+			switch v := v.(*ClearConversationReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1078,6 +2992,270 @@ func file_apps_im_rpc_im_proto_init() {
 			}
 		}
 		file_apps_im_rpc_im_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearConversationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteConversationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteConversationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChatLogReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChatLogResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncMessagesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncMessagesResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchChatLogReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Highlight); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchChatLogHit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchChatLogResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReadRecordsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReadRecordsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecallMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecallMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReactMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReactMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUpUserConversationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUpUserConversationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateGroupConversationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateGroupConversationResp); i {
 			case 0:
 				return &v.state
@@ -1096,7 +3274,7 @@ func file_apps_im_rpc_im_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_apps_im_rpc_im_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,12 +24,29 @@ const _ = grpc.SupportPackageIsVersion7
 type ImClient interface {
 	// 获取会话记录
 	GetChatLog(ctx context.Context, in *GetChatLogReq, opts ...grpc.CallOption) (*GetChatLogResp, error)
+	// 按序号同步会话中缺失的消息
+	SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error)
+	// 搜索聊天记录
+	SearchChatLog(ctx context.Context, in *SearchChatLogReq, opts ...grpc.CallOption) (*SearchChatLogResp, error)
+	// 获取消息的已读用户
+	GetReadRecords(ctx context.Context, in *GetReadRecordsReq, opts ...grpc.CallOption) (*GetReadRecordsResp, error)
+	// 撤回消息
+	RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error)
+	// 编辑消息
+	EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error)
+	// 添加或移除表情回应
+	ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error)
 	// 建立会话: 群聊, 私聊
 	SetUpUserConversation(ctx context.Context, in *SetUpUserConversationReq, opts ...grpc.CallOption) (*SetUpUserConversationResp, error)
 	// 获取会话
 	GetConversations(ctx context.Context, in *GetConversationsReq, opts ...grpc.CallOption) (*GetConversationsResp, error)
 	// 更新会话
 	PutConversations(ctx context.Context, in *PutConversationsReq, opts ...grpc.CallOption) (*PutConversationsResp, error)
+	// 清空会话的聊天记录，只对当前用户生效
+	ClearConversation(ctx context.Context, in *ClearConversationReq, opts ...grpc.CallOption) (*ClearConversationResp, error)
+	// 删除会话，会话中有新消息时重新显示
+	DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error)
+	// 创建群聊
 	CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error)
 }
 
@@ -50,6 +67,60 @@ func (c *imClient) GetChatLog(ctx context.Context, in *GetChatLogReq, opts ...gr
 	return out, nil
 }
 
+func (c *imClient) SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error) {
+	out := new(SyncMessagesResp)
+	err := c.cc.Invoke(ctx, "/im.Im/SyncMessages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) SearchChatLog(ctx context.Context, in *SearchChatLogReq, opts ...grpc.CallOption) (*SearchChatLogResp, error) {
+	out := new(SearchChatLogResp)
+	err := c.cc.Invoke(ctx, "/im.Im/SearchChatLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) GetReadRecords(ctx context.Context, in *GetReadRecordsReq, opts ...grpc.CallOption) (*GetReadRecordsResp, error) {
+	out := new(GetReadRecordsResp)
+	err := c.cc.Invoke(ctx, "/im.Im/GetReadRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error) {
+	out := new(RecallMsgResp)
+	err := c.cc.Invoke(ctx, "/im.Im/RecallMsg", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error) {
+	out := new(EditMsgResp)
+	err := c.cc.Invoke(ctx, "/im.Im/EditMsg", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error) {
+	out := new(ReactMsgResp)
+	err := c.cc.Invoke(ctx, "/im.Im/ReactMsg", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *imClient) SetUpUserConversation(ctx context.Context, in *SetUpUserConversationReq, opts ...grpc.CallOption) (*SetUpUserConversationResp, error) {
 	out := new(SetUpUserConversationResp)
 	err := c.cc.Invoke(ctx, "/im.Im/SetUpUserConversation", in, out, opts...)
@@ -77,6 +148,24 @@ func (c *imClient) PutConversations(ctx context.Context, in *PutConversationsReq
 	return out, nil
 }
 
+func (c *imClient) ClearConversation(ctx context.Context, in *ClearConversationReq, opts ...grpc.CallOption) (*ClearConversationResp, error) {
+	out := new(ClearConversationResp)
+	err := c.cc.Invoke(ctx, "/im.Im/ClearConversation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error) {
+	out := new(DeleteConversationResp)
+	err := c.cc.Invoke(ctx, "/im.Im/DeleteConversation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *imClient) CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error) {
 	out := new(CreateGroupConversationResp)
 	err := c.cc.Invoke(ctx, "/im.Im/CreateGroupConversation", in, out, opts...)
@@ -92,12 +181,29 @@ func (c *imClient) CreateGroupConversation(ctx context.Context, in *CreateGroupC
 type ImServer interface {
 	// 获取会话记录
 	GetChatLog(context.Context, *GetChatLogReq) (*GetChatLogResp, error)
+	// 按序号同步会话中缺失的消息
+	SyncMessages(context.Context, *SyncMessagesReq) (*SyncMessagesResp, error)
+	// 搜索聊天记录
+	SearchChatLog(context.Context, *SearchChatLogReq) (*SearchChatLogResp, error)
+	// 获取消息的已读用户
+	GetReadRecords(context.Context, *GetReadRecordsReq) (*GetReadRecordsResp, error)
+	// 撤回消息
+	RecallMsg(context.Context, *RecallMsgReq) (*RecallMsgResp, error)
+	// 编辑消息
+	EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error)
+	// 添加或移除表情回应
+	ReactMsg(context.Context, *ReactMsgReq) (*ReactMsgResp, error)
 	// 建立会话: 群聊, 私聊
 	SetUpUserConversation(context.Context, *SetUpUserConversationReq) (*SetUpUserConversationResp, error)
 	// 获取会话
 	GetConversations(context.Context, *GetConversationsReq) (*GetConversationsResp, error)
 	// 更新会话
 	PutConversations(context.Context, *PutConversationsReq) (*PutConversationsResp, error)
+	// 清空会话的聊天记录，只对当前用户生效
+	ClearConversation(context.Context, *ClearConversationReq) (*ClearConversationResp, error)
+	// 删除会话，会话中有新消息时重新显示
+	DeleteConversation(context.Context, *DeleteConversationReq) (*DeleteConversationResp, error)
+	// 创建群聊
 	CreateGroupConversation(context.Context, *CreateGroupConversationReq) (*CreateGroupConversationResp, error)
 	mustEmbedUnimplementedImServer()
 }
@@ -109,6 +215,24 @@ type UnimplementedImServer struct {
 func (UnimplementedImServer) GetChatLog(context.Context, *GetChatLogReq) (*GetChatLogResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChatLog not implemented")
 }
+func (UnimplementedImServer) SyncMessages(context.Context, *SyncMessagesReq) (*SyncMessagesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncMessages not implemented")
+}
+func (UnimplementedImServer) SearchChatLog(context.Context, *SearchChatLogReq) (*SearchChatLogResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchChatLog not implemented")
+}
+func (UnimplementedImServer) GetReadRecords(context.Context, *GetReadRecordsReq) (*GetReadRecordsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadRecords not implemented")
+}
+func (UnimplementedImServer) RecallMsg(context.Context, *RecallMsgReq) (*RecallMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecallMsg not implemented")
+}
+func (UnimplementedImServer) EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditMsg not implemented")
+}
+func (UnimplementedImServer) ReactMsg(context.Context, *ReactMsgReq) (*ReactMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactMsg not implemented")
+}
 func (UnimplementedImServer) SetUpUserConversation(context.Context, *SetUpUserConversationReq) (*SetUpUserConversationResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetUpUserConversation not implemented")
 }
@@ -118,6 +242,12 @@ func (UnimplementedImServer) GetConversations(context.Context, *GetConversations
 func (UnimplementedImServer) PutConversations(context.Context, *PutConversationsReq) (*PutConversationsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutConversations not implemented")
 }
+func (UnimplementedImServer) ClearConversation(context.Context, *ClearConversationReq) (*ClearConversationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearConversation not implemented")
+}
+func (UnimplementedImServer) DeleteConversation(context.Context, *DeleteConversationReq) (*DeleteConversationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConversation not implemented")
+}
 func (UnimplementedImServer) CreateGroupConversation(context.Context, *CreateGroupConversationReq) (*CreateGroupConversationResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateGroupConversation not implemented")
 }
@@ -152,6 +282,114 @@ func _Im_GetChatLog_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Im_SyncMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncMessagesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).SyncMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/SyncMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).SyncMessages(ctx, req.(*SyncMessagesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_SearchChatLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchChatLogReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).SearchChatLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/SearchChatLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).SearchChatLog(ctx, req.(*SearchChatLogReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_GetReadRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadRecordsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).GetReadRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/GetReadRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).GetReadRecords(ctx, req.(*GetReadRecordsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_RecallMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecallMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).RecallMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/RecallMsg",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).RecallMsg(ctx, req.(*RecallMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_EditMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).EditMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/EditMsg",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).EditMsg(ctx, req.(*EditMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_ReactMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).ReactMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/ReactMsg",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).ReactMsg(ctx, req.(*ReactMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Im_SetUpUserConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetUpUserConversationReq)
 	if err := dec(in); err != nil {
@@ -206,6 +444,42 @@ func _Im_PutConversations_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Im_ClearConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearConversationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).ClearConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/ClearConversation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).ClearConversation(ctx, req.(*ClearConversationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_DeleteConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConversationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).DeleteConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/im.Im/DeleteConversation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).DeleteConversation(ctx, req.(*DeleteConversationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Im_CreateGroupConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateGroupConversationReq)
 	if err := dec(in); err != nil {
@@ -235,6 +509,30 @@ var Im_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChatLog",
 			Handler:    _Im_GetChatLog_Handler,
 		},
+		{
+			MethodName: "SyncMessages",
+			Handler:    _Im_SyncMessages_Handler,
+		},
+		{
+			MethodName: "SearchChatLog",
+			Handler:    _Im_SearchChatLog_Handler,
+		},
+		{
+			MethodName: "GetReadRecords",
+			Handler:    _Im_GetReadRecords_Handler,
+		},
+		{
+			MethodName: "RecallMsg",
+			Handler:    _Im_RecallMsg_Handler,
+		},
+		{
+			MethodName: "EditMsg",
+			Handler:    _Im_EditMsg_Handler,
+		},
+		{
+			MethodName: "ReactMsg",
+			Handler:    _Im_ReactMsg_Handler,
+		},
 		{
 			MethodName: "SetUpUserConversation",
 			Handler:    _Im_SetUpUserConversation_Handler,
@@ -247,6 +545,14 @@ var Im_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutConversations",
 			Handler:    _Im_PutConversations_Handler,
 		},
+		{
+			MethodName: "ClearConversation",
+			Handler:    _Im_ClearConversation_Handler,
+		},
+		{
+			MethodName: "DeleteConversation",
+			Handler:    _Im_DeleteConversation_Handler,
+		},
 		{
 			MethodName: "CreateGroupConversation",
 			Handler:    _Im_CreateGroupConversation_Handler,
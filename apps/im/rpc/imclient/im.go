@@ -14,27 +14,65 @@ import (
 
 type (
 	ChatLog                     = im.ChatLog
+	ChatLogQuote                = im.ChatLogQuote
+	ChatLogRevision             = im.ChatLogRevision
+	ClearConversationReq        = im.ClearConversationReq
+	ClearConversationResp       = im.ClearConversationResp
 	Conversation                = im.Conversation
 	CreateGroupConversationReq  = im.CreateGroupConversationReq
 	CreateGroupConversationResp = im.CreateGroupConversationResp
+	DeleteConversationReq       = im.DeleteConversationReq
+	DeleteConversationResp      = im.DeleteConversationResp
+	EditMsgReq                  = im.EditMsgReq
+	EditMsgResp                 = im.EditMsgResp
 	GetChatLogReq               = im.GetChatLogReq
 	GetChatLogResp              = im.GetChatLogResp
 	GetConversationsReq         = im.GetConversationsReq
 	GetConversationsResp        = im.GetConversationsResp
+	GetReadRecordsReq           = im.GetReadRecordsReq
+	GetReadRecordsResp          = im.GetReadRecordsResp
+	Highlight                   = im.Highlight
+	MsgPayload                  = im.MsgPayload
 	PutConversationsReq         = im.PutConversationsReq
 	PutConversationsResp        = im.PutConversationsResp
+	ReactMsgReq                 = im.ReactMsgReq
+	ReactMsgResp                = im.ReactMsgResp
+	Reaction                    = im.Reaction
+	RecallMsgReq                = im.RecallMsgReq
+	RecallMsgResp               = im.RecallMsgResp
+	SearchChatLogHit            = im.SearchChatLogHit
+	SearchChatLogReq            = im.SearchChatLogReq
+	SearchChatLogResp           = im.SearchChatLogResp
 	SetUpUserConversationReq    = im.SetUpUserConversationReq
 	SetUpUserConversationResp   = im.SetUpUserConversationResp
+	SyncMessagesReq             = im.SyncMessagesReq
+	SyncMessagesResp            = im.SyncMessagesResp
 
 	Im interface {
 		// 获取会话记录
 		GetChatLog(ctx context.Context, in *GetChatLogReq, opts ...grpc.CallOption) (*GetChatLogResp, error)
+		// 按序号同步会话中缺失的消息
+		SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error)
+		// 搜索聊天记录
+		SearchChatLog(ctx context.Context, in *SearchChatLogReq, opts ...grpc.CallOption) (*SearchChatLogResp, error)
+		// 获取消息的已读用户
+		GetReadRecords(ctx context.Context, in *GetReadRecordsReq, opts ...grpc.CallOption) (*GetReadRecordsResp, error)
+		// 撤回消息
+		RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error)
+		// 编辑消息
+		EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error)
+		// 添加或移除表情回应
+		ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error)
 		// 建立会话: 群聊, 私聊
 		SetUpUserConversation(ctx context.Context, in *SetUpUserConversationReq, opts ...grpc.CallOption) (*SetUpUserConversationResp, error)
 		// 获取会话
 		GetConversations(ctx context.Context, in *GetConversationsReq, opts ...grpc.CallOption) (*GetConversationsResp, error)
 		// 更新会话
 		PutConversations(ctx context.Context, in *PutConversationsReq, opts ...grpc.CallOption) (*PutConversationsResp, error)
+		// 清空会话的聊天记录，只对当前用户生效
+		ClearConversation(ctx context.Context, in *ClearConversationReq, opts ...grpc.CallOption) (*ClearConversationResp, error)
+		// 删除会话，会话中有新消息时重新显示
+		DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error)
 		CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error)
 	}
 
@@ -55,6 +93,42 @@ func (m *defaultIm) GetChatLog(ctx context.Context, in *GetChatLogReq, opts ...g
 	return client.GetChatLog(ctx, in, opts...)
 }
 
+// 按序号同步会话中缺失的消息
+func (m *defaultIm) SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.SyncMessages(ctx, in, opts...)
+}
+
+// 搜索聊天记录
+func (m *defaultIm) SearchChatLog(ctx context.Context, in *SearchChatLogReq, opts ...grpc.CallOption) (*SearchChatLogResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.SearchChatLog(ctx, in, opts...)
+}
+
+// 获取消息的已读用户
+func (m *defaultIm) GetReadRecords(ctx context.Context, in *GetReadRecordsReq, opts ...grpc.CallOption) (*GetReadRecordsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.GetReadRecords(ctx, in, opts...)
+}
+
+// 撤回消息
+func (m *defaultIm) RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.RecallMsg(ctx, in, opts...)
+}
+
+// 编辑消息
+func (m *defaultIm) EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.EditMsg(ctx, in, opts...)
+}
+
+// 添加或移除表情回应
+func (m *defaultIm) ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.ReactMsg(ctx, in, opts...)
+}
+
 // 建立会话: 群聊, 私聊
 func (m *defaultIm) SetUpUserConversation(ctx context.Context, in *SetUpUserConversationReq, opts ...grpc.CallOption) (*SetUpUserConversationResp, error) {
 	client := im.NewImClient(m.cli.Conn())
@@ -73,6 +147,18 @@ func (m *defaultIm) PutConversations(ctx context.Context, in *PutConversationsRe
 	return client.PutConversations(ctx, in, opts...)
 }
 
+// 清空会话的聊天记录，只对当前用户生效
+func (m *defaultIm) ClearConversation(ctx context.Context, in *ClearConversationReq, opts ...grpc.CallOption) (*ClearConversationResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.ClearConversation(ctx, in, opts...)
+}
+
+// 删除会话，会话中有新消息时重新显示
+func (m *defaultIm) DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.DeleteConversation(ctx, in, opts...)
+}
+
 func (m *defaultIm) CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error) {
 	client := im.NewImClient(m.cli.Conn())
 	return client.CreateGroupConversation(ctx, in, opts...)
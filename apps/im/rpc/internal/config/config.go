@@ -1,6 +1,9 @@
 package config
 
-import "github.com/zeromicro/go-zero/zrpc"
+import (
+	"github.com/zeromicro/go-zero/zrpc"
+	"time"
+)
 
 type Config struct {
 	zrpc.RpcServerConf
@@ -9,4 +12,15 @@ type Config struct {
 		Url string
 		Db  string
 	}
+
+	SocialRpc zrpc.RpcClientConf
+
+	// 消息变更通知（撤回、编辑等）的消息队列
+	MsgNoticeTransfer struct {
+		Topic string
+		Addrs []string
+	}
+
+	// 发送者可以撤回消息的时间窗口，群主与管理员撤回成员消息不受限制
+	MsgRecallWindow time.Duration `json:",default=2m"`
 }
@@ -0,0 +1,194 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"github.com/pkg/errors"
+	"sort"
+)
+
+// toChatLog 将聊天记录转换为 rpc 响应中的聊天记录。
+//
+// 已撤回的消息以墓碑形式返回：保留消息的ID、序号等元信息与撤回状态，不返回消息内容、附加内容与编辑历史。
+//
+// 参数:
+//   - v: 数据库中的聊天记录。
+//   - withRevisions: 是否返回消息的编辑历史。
+//
+// 返回值:
+//   - *im.ChatLog: rpc 响应中的聊天记录。
+func toChatLog(v *immodels.ChatLog, withRevisions bool) *im.ChatLog {
+	chatLog := &im.ChatLog{
+		Id:             v.ID.Hex(),
+		ConversationId: v.ConversationId,
+		SendId:         v.SendId,
+		RecvId:         v.RecvId,
+		MsgType:        int32(v.MsgType),
+		MsgContent:     v.MsgContent,
+		ChatType:       int32(v.ChatType),
+		SendTime:       v.SendTime,
+		ReadRecords:    v.ReadRecords,
+		ReadCount:      v.ReadCount,
+		Seq:            v.Seq,
+		Status:         int32(v.Status),
+		EditedAt:       v.EditedAt,
+		ReplyTo:        v.ReplyTo,
+		Mentions:       v.Mentions,
+		MentionAll:     v.MentionAll,
+	}
+	if v.Status == constants.RecalledMsgStatus {
+		chatLog.MsgContent = ""
+		return chatLog
+	}
+
+	if p := v.Payload; p != nil {
+		chatLog.Payload = &im.MsgPayload{
+			FileId:    p.FileId,
+			Url:       p.Url,
+			Thumb:     p.Thumb,
+			Width:     p.Width,
+			Height:    p.Height,
+			Name:      p.Name,
+			Size:      p.Size,
+			Mime:      p.Mime,
+			Duration:  p.Duration,
+			Latitude:  p.Latitude,
+			Longitude: p.Longitude,
+			Address:   p.Address,
+			CardType:  int32(p.CardType),
+			TargetId:  p.TargetId,
+			Avatar:    p.Avatar,
+		}
+	}
+
+	chatLog.Reactions = toReactions(v.Reactions)
+
+	if withRevisions {
+		chatLog.Revisions = make([]*im.ChatLogRevision, 0, len(v.Revisions))
+		for _, revision := range v.Revisions {
+			chatLog.Revisions = append(chatLog.Revisions, &im.ChatLogRevision{
+				MsgContent: revision.MsgContent,
+				EditedAt:   revision.EditedAt,
+			})
+		}
+	}
+	return chatLog
+}
+
+// quoteContentLimit 引用摘要中消息内容保留的最大字符数。
+const quoteContentLimit = 100
+
+// attachQuotes 为引用回复的消息附加被引用消息的摘要。
+//
+// 被引用的消息批量查询，摘要包含发送者、消息类型、截断后的内容与是否已撤回，
+// 已撤回的消息不返回内容，已被删除的消息不附加摘要。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - model: 聊天记录模型。
+//   - list: rpc 响应中的聊天记录。
+//
+// 返回值:
+//   - error: 查询被引用的消息失败时返回错误。
+func attachQuotes(ctx context.Context, model immodels.ChatLogModel, list []*im.ChatLog) error {
+	ids := make([]string, 0)
+	for _, v := range list {
+		if v.ReplyTo != "" {
+			ids = append(ids, v.ReplyTo)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	quoted, err := model.ListByMsgIds(ctx, ids)
+	if err != nil && err != immodels.ErrNotFound {
+		return err
+	}
+	quotes := make(map[string]*im.ChatLogQuote, len(quoted))
+	for _, v := range quoted {
+		quote := &im.ChatLogQuote{
+			Id:       v.ID.Hex(),
+			SendId:   v.SendId,
+			MsgType:  int32(v.MsgType),
+			Recalled: v.Status == constants.RecalledMsgStatus,
+		}
+		if !quote.Recalled {
+			quote.MsgContent = truncate(v.MsgContent, quoteContentLimit)
+		}
+		quotes[quote.Id] = quote
+	}
+
+	for _, v := range list {
+		if v.ReplyTo != "" {
+			v.Quote = quotes[v.ReplyTo]
+		}
+	}
+	return nil
+}
+
+// truncate 按字符截断字符串，超出部分以省略号代替。
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
+
+// toReactions 将聊天记录中的表情回应转换为 rpc 响应中的列表，按回应人数从多到少排列，人数相同时按表情排序。
+func toReactions(reactions map[string][]string) []*im.Reaction {
+	if len(reactions) == 0 {
+		return nil
+	}
+
+	res := make([]*im.Reaction, 0, len(reactions))
+	for emoji, userIds := range reactions {
+		if len(userIds) == 0 {
+			continue
+		}
+		res = append(res, &im.Reaction{
+			Emoji:   emoji,
+			Count:   int32(len(userIds)),
+			UserIds: userIds,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Emoji < res[j].Emoji
+	})
+	return res
+}
+
+// clearedSeq 获取用户清空会话聊天记录的位置，不超过该序号的消息对用户不可见。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - model: 用户会话列表的数据模型。
+//   - uid: 用户ID，为空时不做限制。
+//   - conversationId: 会话ID。
+//
+// 返回值:
+//   - int64: 清空时会话的最大序号，用户未清空过聊天记录时返回 0。
+//   - error: 查询失败时返回错误。
+func clearedSeq(ctx context.Context, model immodels.ConversationsModel, uid, conversationId string) (int64, error) {
+	if uid == "" {
+		return 0, nil
+	}
+
+	data, err := model.FindByUserId(ctx, uid)
+	switch {
+	case errors.Is(err, immodels.ErrNotFound):
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+	if conversation, ok := data.ConversationList[conversationId]; ok {
+		return conversation.ClearedSeq, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var ErrConversationNotFound = xerr.NewMsg("会话不存在")
+
+type ClearConversationLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewClearConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ClearConversationLogic {
+	return &ClearConversationLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ClearConversation 清空会话的聊天记录，只对当前用户生效。
+//
+// 聊天记录本身不会被删除，会话的另一方或其他群成员不受影响。该方法在用户的会话上记录清空时会话的最大序号，
+// 之后查询、同步与搜索聊天记录时不再返回不超过该序号的消息，同时将会话中已有的消息全部标记为已读。
+//
+// 参数:
+//   - in: 请求对象，包含用户ID与会话ID。
+//
+// 返回值:
+//   - *im.ClearConversationResp: 空响应。
+//   - error: 会话不存在或更新失败时返回错误。
+func (l *ClearConversationLogic) ClearConversation(in *im.ClearConversationReq) (*im.ClearConversationResp, error) {
+	if err := clearConversation(l.ctx, l.svcCtx, in.UserId, in.ConversationId, false); err != nil {
+		return nil, err
+	}
+	return &im.ClearConversationResp{}, nil
+}
+
+// clearConversation 在用户的会话列表中记录会话的清空位置，并将会话中已有的消息标记为已读。
+//
+// 只更新用户会话的清空位置、删除标记与已读序号等字段，不会覆盖并发的已读与个人设置更新。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - svcCtx: 服务上下文。
+//   - uid: 用户ID。
+//   - conversationId: 会话ID。
+//   - deleted: 是否同时从用户的会话列表中删除会话。
+//
+// 返回值:
+//   - error: 会话不存在或更新失败时返回错误。
+func clearConversation(ctx context.Context, svcCtx *svc.ServiceContext, uid, conversationId string, deleted bool) error {
+	conversations, err := svcCtx.ConversationModel.ListByConversationIds(ctx, []string{conversationId})
+	if err != nil && !errors.Is(err, immodels.ErrNotFound) {
+		return errors.Wrapf(xerr.NewDBErr(), "list conversations by conversation ids failed, err: %v, id: %v", err, conversationId)
+	}
+	var (
+		seq   int64
+		total int
+	)
+	if len(conversations) > 0 {
+		seq, total = conversations[0].Seq, conversations[0].Total
+	}
+
+	err = svcCtx.ConversationsModel.ClearConversation(ctx, uid, conversationId, seq, total, deleted)
+	switch {
+	case errors.Is(err, immodels.ErrNotFound):
+		return errors.WithStack(ErrConversationNotFound)
+	case err != nil:
+		return errors.Wrapf(xerr.NewDBErr(), "clear conversation failed, uid: %s, id: %s, err: %v", uid, conversationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type fakeConversationModel struct {
+	immodels.ConversationModel
+	conversation *immodels.Conversation
+}
+
+func (m *fakeConversationModel) ListByConversationIds(ctx context.Context, ids []string) ([]*immodels.Conversation, error) {
+	return []*immodels.Conversation{m.conversation}, nil
+}
+
+func TestClearAndDeleteConversation(t *testing.T) {
+	conversations := &fakeConversationsModel{data: &immodels.Conversations{
+		UserId:           "u1",
+		ConversationList: map[string]*immodels.Conversation{"c1": {ConversationId: "c1"}},
+	}}
+	svcCtx := &svc.ServiceContext{
+		ConversationModel:  &fakeConversationModel{conversation: &immodels.Conversation{ConversationId: "c1", Seq: 8, Total: 8}},
+		ConversationsModel: conversations,
+	}
+	ctx := context.Background()
+
+	if _, err := NewClearConversationLogic(ctx, svcCtx).ClearConversation(&im.ClearConversationReq{UserId: "u1", ConversationId: "c1"}); err != nil {
+		t.Fatalf("ClearConversation err: %v", err)
+	}
+	if _, err := NewDeleteConversationLogic(ctx, svcCtx).DeleteConversation(&im.DeleteConversationReq{UserId: "u1", ConversationId: "c1"}); err != nil {
+		t.Fatalf("DeleteConversation err: %v", err)
+	}
+	want := []clearCall{{"u1", "c1", 8, 8, false}, {"u1", "c1", 8, 8, true}}
+	if !reflect.DeepEqual(conversations.cleared, want) {
+		t.Errorf("cleared = %+v, want %+v", conversations.cleared, want)
+	}
+
+	_, err := NewClearConversationLogic(ctx, svcCtx).ClearConversation(&im.ClearConversationReq{UserId: "u1", ConversationId: "c2"})
+	if !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("ClearConversation missing conversation err = %v, want %v", err, ErrConversationNotFound)
+	}
+}
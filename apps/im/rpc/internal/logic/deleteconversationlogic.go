@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteConversationLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeleteConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteConversationLogic {
+	return &DeleteConversationLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// DeleteConversation 从用户的会话列表中删除会话，只对当前用户生效。
+//
+// 删除会话的同时清空会话的聊天记录。会话在用户的会话列表中保留清空位置，但不再出现在获取的会话列表中，
+// 会话中有新消息时会自动重新出现，且只包含删除之后的消息。
+//
+// 参数:
+//   - in: 请求对象，包含用户ID与会话ID。
+//
+// 返回值:
+//   - *im.DeleteConversationResp: 空响应。
+//   - error: 会话不存在或更新失败时返回错误。
+func (l *DeleteConversationLogic) DeleteConversation(in *im.DeleteConversationReq) (*im.DeleteConversationResp, error) {
+	if err := clearConversation(l.ctx, l.svcCtx, in.UserId, in.ConversationId, true); err != nil {
+		return nil, err
+	}
+	return &im.DeleteConversationResp{}, nil
+}
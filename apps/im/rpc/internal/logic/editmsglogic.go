@@ -0,0 +1,107 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrEditNotAllowed   = xerr.NewMsg("只能编辑自己发送的消息")
+	ErrEditNotText      = xerr.NewMsg("只能编辑文本消息")
+	ErrEditRecalled     = xerr.NewMsg("消息已撤回")
+	ErrEditConcurrently = xerr.NewMsg("消息已被修改，请刷新后重试")
+	ErrEditEmpty        = xerr.NewMsg("消息内容不能为空")
+)
+
+type EditMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewEditMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *EditMsgLogic {
+	return &EditMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// EditMsg 编辑消息。
+//
+// 只有消息的发送者可以编辑自己发送的文本消息，已撤回的消息不能编辑，编辑后的内容不能为空。
+// 编辑后更新聊天记录的内容与编辑时间，编辑前的内容保存为历史版本，
+// 并通过消息队列发布编辑通知，由 task 服务推送给会话中的其他成员以便在线客户端原地更新。
+//
+// 参数:
+//   - in: 请求对象，包含消息ID、执行编辑的用户ID与新的消息内容。
+//
+// 返回值:
+//   - *im.EditMsgResp: 编辑结果，包含编辑时间。
+//   - error: 没有权限、消息不可编辑或操作失败时返回错误。
+func (l *EditMsgLogic) EditMsg(in *im.EditMsgReq) (*im.EditMsgResp, error) {
+	if strings.TrimSpace(in.MsgContent) == "" {
+		return nil, errors.WithStack(ErrEditEmpty)
+	}
+
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err: %v, req: %v", err, in)
+	}
+
+	switch {
+	case chatLog.SendId != in.UserId:
+		return nil, errors.WithStack(ErrEditNotAllowed)
+	case chatLog.MsgType != constants.TextMType:
+		return nil, errors.WithStack(ErrEditNotText)
+	case chatLog.Status == constants.RecalledMsgStatus:
+		return nil, errors.WithStack(ErrEditRecalled)
+	case chatLog.MsgContent == in.MsgContent:
+		// 内容没有变化
+		return &im.EditMsgResp{EditedAt: chatLog.EditedAt}, nil
+	}
+
+	// 更新消息内容并记录历史版本
+	editedAt := time.Now().UnixMilli()
+	err = l.svcCtx.ChatLogModel.UpdateContent(l.ctx, chatLog, in.MsgContent, editedAt)
+	if err != nil {
+		if errors.Is(err, immodels.ErrNotFound) {
+			return nil, errors.WithStack(ErrEditConcurrently)
+		}
+		return nil, errors.Wrapf(xerr.NewDBErr(), "update chatlog content err: %v, req: %v", err, in)
+	}
+	if err := l.svcCtx.ConversationModel.UpdateLastMsg(l.ctx, chatLog); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "update conversation msg err: %v, req: %v", err, in)
+	}
+
+	// 发布编辑通知
+	err = l.svcCtx.MsgNoticeTransferClient.Push(&mq.MsgNotice{
+		ContentType:    constants.ContentEdit,
+		ChatType:       chatLog.ChatType,
+		ConversationId: chatLog.ConversationId,
+		SendId:         in.UserId,
+		RecvId:         chatLog.RecvId,
+		MsgId:          in.MsgId,
+		Seq:            chatLog.Seq,
+		Content:        chatLog.MsgContent,
+		EditedAt:       editedAt,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "push edit notice err, req: %v", in)
+	}
+
+	return &im.EditMsgResp{
+		EditedAt: editedAt,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestEditMsgEmptyContent(t *testing.T) {
+	l := NewEditMsgLogic(context.Background(), &svc.ServiceContext{})
+
+	for _, content := range []string{"", "  ", "\n\t"} {
+		_, err := l.EditMsg(&im.EditMsgReq{MsgId: "m1", UserId: "u1", MsgContent: content})
+		if !errors.Is(err, ErrEditEmpty) {
+			t.Errorf("EditMsg(%q) err = %v, want %v", content, err, ErrEditEmpty)
+		}
+	}
+}
@@ -2,8 +2,10 @@ package logic
 
 import (
 	"context"
+	"easy-chat/apps/im/immodels"
 	"easy-chat/pkg/xerr"
 	"github.com/pkg/errors"
+	"slices"
 
 	"easy-chat/apps/im/rpc/im"
 	"easy-chat/apps/im/rpc/internal/svc"
@@ -11,6 +13,28 @@ import (
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
+// 聊天记录的分页方向
+const (
+	pageOlder  int32 = iota // 查询更早的消息
+	pageNewer               // 查询更新的消息
+	pageAround              // 查询指定消息前后的消息
+)
+
+var (
+	ErrChatLogDirection = xerr.NewMsg("无效的分页方向")
+	ErrChatLogCursor    = xerr.NewMsg("无效的分页游标")
+	ErrChatLogAnchor    = xerr.NewMsg("消息不属于该会话")
+	ErrChatLogCleared   = xerr.NewMsg("消息已被清空")
+)
+
+// chatLogPage 一页聊天记录
+type chatLogPage struct {
+	list     []*immodels.ChatLog // 按发送时间从新到旧排列
+	hasMore  bool                // 请求的方向上是否还有更多消息
+	hasOlder bool                // 是否还有更早的消息
+	hasNewer bool                // 是否还有更新的消息
+}
+
 type GetChatLogLogic struct {
 	ctx    context.Context
 	svcCtx *svc.ServiceContext
@@ -27,9 +51,11 @@ func NewGetChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCha
 
 // GetChatLog 获取会话记录。
 //
-// 该方法根据请求中的参数从数据库中获取聊天记录。根据是否提供了 msgId，
-// 方法会选择不同的查询方式：如果 msgId 不为空，则直接查询该消息记录；
-// 如果 msgId 为空，则根据时间段进行查询。查询的结果会按照时间排序，并返回符合条件的聊天记录。
+// 如果请求中提供了 msgId 且未指定分页方向，直接查询该消息记录；否则按游标分页查询会话中的聊天记录。
+// 游标由消息的发送时间与消息ID组成，同一毫秒内的消息也不会被跳过或重复。分页支持三个方向：
+// older 查询游标之前更早的消息，未携带游标时从最新的消息开始；newer 查询游标之后更新的消息；
+// around 以 msgId 所指的消息为中心查询其前后的消息，用于从引用或搜索结果跳转到消息所在位置。
+// 返回的消息按发送时间从新到旧排列，并返回向两个方向继续查询的游标。提供了 userId 时，用户已清空的消息不再返回。引用回复的消息会附带被引用消息的摘要。
 //
 // 参数:
 //   - in: 请求对象，包含查询条件。
@@ -39,54 +65,192 @@ func NewGetChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCha
 //   - *im.GetChatLogResp: 查询结果的响应对象。
 func (l *GetChatLogLogic) GetChatLog(in *im.GetChatLogReq) (*im.GetChatLogResp, error) {
 	// 如果请求中提供了 msgId，直接查询该消息记录
-	if in.MsgId != "" {
+	if in.MsgId != "" && in.Direction != pageAround {
 		chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
 		if err != nil {
 			// 如果查询过程中发生错误，返回包装后的错误信息
 			return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId %s failed", in.MsgId)
 		}
+		// 用户已清空的消息不再返回，未清空过的会话中没有序号的旧消息照常返回
+		cleared, err := clearedSeq(l.ctx, l.svcCtx.ConversationsModel, in.UserId, chatLog.ConversationId)
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "find cleared seq failed, err: %v req: %v", err, in)
+		}
+		if cleared > 0 && chatLog.Seq <= cleared {
+			return &im.GetChatLogResp{}, nil
+		}
 		// 构造并返回响应对象，包含查询到的单条聊天记录
+		res := []*im.ChatLog{toChatLog(chatLog, in.WithRevisions)}
+		if err := attachQuotes(l.ctx, l.svcCtx.ChatLogModel, res); err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "find quoted chatLog failed, err: %v req: %v", err.Error(), in)
+		}
 		return &im.GetChatLogResp{
-			List: []*im.ChatLog{
-				{
-					Id:             chatLog.ID.Hex(),
-					ConversationId: chatLog.ConversationId,
-					SendId:         chatLog.SendId,
-					RecvId:         chatLog.RecvId,
-					MsgType:        int32(chatLog.MsgType),
-					MsgContent:     chatLog.MsgContent,
-					ChatType:       int32(chatLog.ChatType),
-					SendTime:       chatLog.SendTime,
-					ReadRecords:    chatLog.ReadRecords,
-				},
-			},
+			List: res,
 		}, nil
 	}
 
-	// 如果没有提供 msgId，基于时间范围查询聊天记录
-	data, err := l.svcCtx.ChatLogModel.ListBySendTime(l.ctx, in.ConversationId, in.StartSendTime, in.EndSendTime, in.Count)
+	limit := in.Count
+	if limit <= 0 || limit > immodels.DefaultChatLogLimit {
+		limit = immodels.DefaultChatLogLimit
+	}
+
+	var (
+		page *chatLogPage
+		err  error
+	)
+	switch in.Direction {
+	case pageOlder, pageNewer:
+		page, err = l.listByCursor(in, limit)
+	case pageAround:
+		page, err = l.listAround(in, limit)
+	default:
+		return nil, errors.WithStack(ErrChatLogDirection)
+	}
 	if err != nil {
-		// 如果查询过程中发生错误，返回包装后的错误信息
-		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatLog list by SendTime failed, err: %v req: %v", err.Error(), in)
+		return nil, err
 	}
 
 	// 构造查询结果列表
-	res := make([]*im.ChatLog, 0, len(data))
-	for _, v := range data {
-		res = append(res, &im.ChatLog{
-			Id:             v.ID.Hex(),
-			ConversationId: v.ConversationId,
-			SendId:         v.SendId,
-			RecvId:         v.RecvId,
-			MsgType:        int32(v.MsgType),
-			MsgContent:     v.MsgContent,
-			ChatType:       int32(v.ChatType),
-			SendTime:       v.SendTime,
-			ReadRecords:    v.ReadRecords,
-		})
-	}
-	// 返回包含聊天记录列表的响应对象
-	return &im.GetChatLogResp{
-		List: res,
-	}, nil
+	res := make([]*im.ChatLog, 0, len(page.list))
+	for _, v := range page.list {
+		res = append(res, toChatLog(v, in.WithRevisions))
+	}
+	// 附加被引用消息的摘要
+	if err := attachQuotes(l.ctx, l.svcCtx.ChatLogModel, res); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find quoted chatLog failed, err: %v req: %v", err.Error(), in)
+	}
+
+	resp := &im.GetChatLogResp{
+		List:    res,
+		HasMore: page.hasMore,
+	}
+	if len(page.list) > 0 {
+		if page.hasOlder {
+			resp.NextCursor = immodels.NewChatLogCursor(page.list[len(page.list)-1]).String()
+		}
+		if page.hasNewer {
+			resp.PrevCursor = immodels.NewChatLogCursor(page.list[0]).String()
+		}
+	}
+	return resp, nil
+}
+
+// listByCursor 从游标开始向一个方向查询聊天记录。
+func (l *GetChatLogLogic) listByCursor(in *im.GetChatLogReq, limit int64) (*chatLogPage, error) {
+	cleared, err := clearedSeq(l.ctx, l.svcCtx.ConversationsModel, in.UserId, in.ConversationId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find cleared seq failed, err: %v req: %v", err, in)
+	}
+
+	query := &immodels.ChatLogPage{
+		Newer:         in.Direction == pageNewer,
+		AfterSendTime: in.EndSendTime,
+		AfterSeq:      cleared,
+	}
+	switch {
+	case in.Cursor != "":
+		cursor, err := immodels.ParseChatLogCursor(in.Cursor)
+		if err != nil {
+			return nil, errors.WithStack(ErrChatLogCursor)
+		}
+		query.Cursor = cursor
+	case in.StartSendTime > 0 && !query.Newer:
+		// 兼容按发送时间查询，包含 startSendTime 时刻发送的消息
+		query.Cursor = &immodels.ChatLogCursor{
+			SendTime: in.StartSendTime + 1,
+		}
+	}
+
+	// 多查询一条用于判断是否还有更多消息
+	data, err := l.svcCtx.ChatLogModel.ListByCursor(l.ctx, in.ConversationId, query, limit+1)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatLog list by cursor failed, err: %v req: %v", err.Error(), in)
+	}
+	hasMore := int64(len(data)) > limit
+	if hasMore {
+		data = data[:limit]
+	}
+
+	// 从游标开始查询时，查询游标另一侧的一条消息，判断反方向是否还有消息
+	var hasOpposite bool
+	if query.Cursor != nil {
+		opposite := *query
+		opposite.Newer = !query.Newer
+		list, err := l.svcCtx.ChatLogModel.ListByCursor(l.ctx, in.ConversationId, &opposite, 1)
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "find opposite chatLog by cursor failed, err: %v req: %v", err.Error(), in)
+		}
+		hasOpposite = len(list) > 0
+	}
+
+	page := &chatLogPage{
+		list:    data,
+		hasMore: hasMore,
+	}
+	if query.Newer {
+		// 统一按从新到旧排列
+		slices.Reverse(page.list)
+		page.hasNewer, page.hasOlder = hasMore, hasOpposite
+	} else {
+		page.hasOlder, page.hasNewer = hasMore, hasOpposite
+	}
+	return page, nil
+}
+
+// listAround 查询 msgId 所指消息前后的聊天记录，结果包含该消息本身。
+func (l *GetChatLogLogic) listAround(in *im.GetChatLogReq, limit int64) (*chatLogPage, error) {
+	anchor, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId %s failed, err: %v", in.MsgId, err)
+	}
+	if in.ConversationId != "" && anchor.ConversationId != in.ConversationId {
+		return nil, errors.WithStack(ErrChatLogAnchor)
+	}
+	cleared, err := clearedSeq(l.ctx, l.svcCtx.ConversationsModel, in.UserId, anchor.ConversationId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find cleared seq failed, err: %v req: %v", err, in)
+	}
+	if cleared > 0 && anchor.Seq <= cleared {
+		return nil, errors.WithStack(ErrChatLogCleared)
+	}
+
+	cursor := immodels.NewChatLogCursor(anchor)
+	older := (limit - 1) / 2
+	newer := limit - 1 - older
+
+	olderList, err := l.svcCtx.ChatLogModel.ListByCursor(l.ctx, anchor.ConversationId, &immodels.ChatLogPage{
+		Cursor:        cursor,
+		AfterSendTime: in.EndSendTime,
+		AfterSeq:      cleared,
+	}, older+1)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find older chatLog list failed, err: %v req: %v", err.Error(), in)
+	}
+	newerList, err := l.svcCtx.ChatLogModel.ListByCursor(l.ctx, anchor.ConversationId, &immodels.ChatLogPage{
+		Cursor: cursor,
+		Newer:  true,
+	}, newer+1)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find newer chatLog list failed, err: %v req: %v", err.Error(), in)
+	}
+
+	page := &chatLogPage{
+		hasOlder: int64(len(olderList)) > older,
+		hasNewer: int64(len(newerList)) > newer,
+	}
+	if page.hasOlder {
+		olderList = olderList[:older]
+	}
+	if page.hasNewer {
+		newerList = newerList[:newer]
+	}
+	page.hasMore = page.hasOlder || page.hasNewer
+
+	// 统一按从新到旧排列
+	slices.Reverse(newerList)
+	page.list = make([]*immodels.ChatLog, 0, len(newerList)+1+len(olderList))
+	page.list = append(page.list, newerList...)
+	page.list = append(page.list, anchor)
+	page.list = append(page.list, olderList...)
+	return page, nil
 }
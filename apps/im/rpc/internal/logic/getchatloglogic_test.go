@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeChatLogModel 只包含一条聊天记录，游标查询没有其他消息
+type fakeChatLogModel struct {
+	immodels.ChatLogModel
+	chatLog *immodels.ChatLog
+}
+
+func (m *fakeChatLogModel) FindOne(ctx context.Context, id string) (*immodels.ChatLog, error) {
+	if id != m.chatLog.ID.Hex() {
+		return nil, immodels.ErrNotFound
+	}
+	return m.chatLog, nil
+}
+
+func (m *fakeChatLogModel) ListByCursor(ctx context.Context, conversationId string, page *immodels.ChatLogPage, limit int64) ([]*immodels.ChatLog, error) {
+	return nil, nil
+}
+
+type fakeConversationsModel struct {
+	immodels.ConversationsModel
+	data    *immodels.Conversations
+	cleared []clearCall
+}
+
+type clearCall struct {
+	uid, conversationId string
+	seq                 int64
+	total               int
+	deleted             bool
+}
+
+func (m *fakeConversationsModel) ClearConversation(ctx context.Context, uid, conversationId string, seq int64, total int, deleted bool) error {
+	if m.data.ConversationList[conversationId] == nil {
+		return immodels.ErrNotFound
+	}
+	m.cleared = append(m.cleared, clearCall{uid, conversationId, seq, total, deleted})
+	return nil
+}
+
+func (m *fakeConversationsModel) FindByUserId(ctx context.Context, uid string) (*immodels.Conversations, error) {
+	return m.data, nil
+}
+
+func TestGetChatLogLegacySeq(t *testing.T) {
+	// 引入会话内序号之前保存的消息序号为 0，用户没有清空过会话
+	chatLog := &immodels.ChatLog{ID: primitive.NewObjectID(), ConversationId: "c1", SendId: "u2", MsgContent: "hello"}
+	l := NewGetChatLogLogic(context.Background(), &svc.ServiceContext{
+		ChatLogModel: &fakeChatLogModel{chatLog: chatLog},
+		ConversationsModel: &fakeConversationsModel{data: &immodels.Conversations{
+			UserId:           "u1",
+			ConversationList: map[string]*immodels.Conversation{"c1": {ConversationId: "c1"}},
+		}},
+	})
+
+	for _, direction := range []int32{pageOlder, pageAround} {
+		resp, err := l.GetChatLog(&im.GetChatLogReq{UserId: "u1", ConversationId: "c1", MsgId: chatLog.ID.Hex(), Direction: direction})
+		if err != nil {
+			t.Fatalf("direction %d: GetChatLog err: %v", direction, err)
+		}
+		if len(resp.List) != 1 || resp.List[0].Id != chatLog.ID.Hex() {
+			t.Errorf("direction %d: list = %v, want the seq-0 message", direction, resp.List)
+		}
+	}
+}
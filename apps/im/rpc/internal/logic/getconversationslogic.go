@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
 	"easy-chat/pkg/xerr"
 	"github.com/jinzhu/copier"
 	"github.com/pkg/errors"
@@ -27,7 +28,17 @@ func NewGetConversationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 	}
 }
 
-// GetConversations 获取会话
+// GetConversations 获取会话。
+//
+// 返回用户的会话列表，并根据会话的最大序号与用户的已读序号计算每个会话的未读消息数，
+// 群聊会话还会统计未读消息中@我的消息数。已删除且没有新消息的会话不会返回。
+//
+// 参数:
+//   - in: 请求对象，包含用户ID。
+//
+// 返回值:
+//   - *im.GetConversationsResp: 用户的会话列表。
+//   - error: 查询失败时返回错误。
 func (l *GetConversationsLogic) GetConversations(in *im.GetConversationsReq) (*im.GetConversationsResp, error) {
 	// 根据用户查询用户的会话列表
 	data, err := l.svcCtx.ConversationsModel.FindByUserId(l.ctx, in.UserId)
@@ -51,22 +62,39 @@ func (l *GetConversationsLogic) GetConversations(in *im.GetConversationsReq) (*i
 		return nil, errors.Wrapf(xerr.NewDBErr(), "list conversations by conversation ids failed, err: %v, req: %v", err, in)
 	}
 
-	// 计算是否存在未读消息
+	// 计算未读消息数；已删除的会话在有新消息时重新显示
 	for _, conversation := range conversations {
+		c, ok := res.ConversationList[conversation.ConversationId]
 		// 如果该会话不存在，则跳过
-		if _, ok := res.ConversationList[conversation.ConversationId]; !ok {
+		if !ok {
 			continue
 		}
-		// 用户读取的消息量
-		total := res.ConversationList[conversation.ConversationId].Total
-		if total < int32(conversation.Total) {
-			// 有新的消息
-			res.ConversationList[conversation.ConversationId].Total = int32(conversation.Total)
-			// 有多少是未读
-			res.ConversationList[conversation.ConversationId].ToRead = int32(conversation.Total) - total
-			// 更改当前会话为显示状态
-			res.ConversationList[conversation.ConversationId].IsShow = true
+		entry := data.ConversationList[conversation.ConversationId]
+		if entry.Deleted && conversation.Seq <= entry.ClearedSeq {
+			delete(res.ConversationList, conversation.ConversationId)
+			continue
+		}
+
+		c.Seq = conversation.Seq
+		c.Total = int32(conversation.Total)
+		c.ToRead = int32(entry.UnreadCount(conversation))
+		if c.ToRead > 0 {
+			// 有未读消息时更改当前会话为显示状态
+			c.IsShow = true
+		}
+	}
+
+	// 统计群聊未读消息中@我的消息数，未读消息为会话中序号最大的 ToRead 条消息
+	for _, conversation := range conversations {
+		c, ok := res.ConversationList[conversation.ConversationId]
+		if !ok || c.ToRead <= 0 || conversation.ChatType != constants.GroupChatType {
+			continue
+		}
+		mentioned, err := l.svcCtx.ChatLogModel.CountMentions(l.ctx, conversation.ConversationId, in.UserId, conversation.Seq-int64(c.ToRead))
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "count mentions failed, err: %v, req: %v", err, in)
 		}
+		c.Mentioned = int32(mentioned)
 	}
 
 	return &res, nil
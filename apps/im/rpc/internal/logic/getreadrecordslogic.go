@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetReadRecordsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetReadRecordsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetReadRecordsLogic {
+	return &GetReadRecordsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetReadRecords 获取消息的已读用户。
+//
+// 群聊的已读用户为精确记录的用户ID列表。尚未迁移的旧消息只保存了哈希位图，此时根据当前群成员推算已读用户，
+// 推算结果可能不精确，消息下一次被标记已读时会迁移为精确记录。
+// 单聊不逐条记录已读，接收者的已读序号不小于消息序号时视为已读。
+//
+// 参数:
+//   - in: 请求对象，包含消息ID。
+//
+// 返回值:
+//   - *im.GetReadRecordsResp: 消息与已读该消息的用户ID。
+//   - error: 查询失败时返回错误。
+func (l *GetReadRecordsLogic) GetReadRecords(in *im.GetReadRecordsReq) (*im.GetReadRecordsResp, error) {
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err: %v, req: %v", err, in)
+	}
+
+	readBy := chatLog.ReadBy
+	if chatLog.HasLegacyReadRecords() {
+		var members []string
+		if chatLog.ChatType == constants.GroupChatType {
+			users, err := l.svcCtx.Social.GroupUsers(l.ctx, &socialclient.GroupUsersReq{
+				GroupId: chatLog.RecvId,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "get group users err, groupId: %v", chatLog.RecvId)
+			}
+			for _, user := range users.List {
+				members = append(members, user.UserId)
+			}
+		}
+		readBy = chatLog.LegacyReadBy(members)
+	}
+
+	// 单聊根据接收者的已读序号判断是否已读
+	if chatLog.ChatType == constants.SingleChatType && len(readBy) == 0 {
+		read, err := l.readByWatermark(chatLog.RecvId, chatLog.ConversationId, chatLog.Seq)
+		if err != nil {
+			return nil, err
+		}
+		if read {
+			readBy = []string{chatLog.RecvId}
+		}
+	}
+
+	return &im.GetReadRecordsResp{
+		ChatLog: toChatLog(chatLog, false),
+		ReadBy:  readBy,
+	}, nil
+}
+
+// readByWatermark 根据用户在会话中的已读序号判断序号为 seq 的消息是否已读。
+func (l *GetReadRecordsLogic) readByWatermark(uid, conversationId string, seq int64) (bool, error) {
+	if seq <= 0 {
+		return false, nil
+	}
+	data, err := l.svcCtx.ConversationsModel.FindByUserId(l.ctx, uid)
+	switch {
+	case errors.Is(err, immodels.ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, errors.Wrapf(xerr.NewDBErr(), "find conversations by user id failed, uid: %s, err: %v", uid, err)
+	}
+	conversation, ok := data.ConversationList[conversationId]
+	return ok && conversation.ReadSeq >= seq, nil
+}
@@ -6,6 +6,7 @@ import (
 	"easy-chat/pkg/constants"
 	"easy-chat/pkg/xerr"
 	"github.com/pkg/errors"
+	"unicode/utf8"
 
 	"easy-chat/apps/im/rpc/im"
 	"easy-chat/apps/im/rpc/internal/svc"
@@ -13,6 +14,14 @@ import (
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
+// maxDisplayNameLen 自定义会话名称的最大字符数
+const maxDisplayNameLen = 64
+
+var (
+	ErrConversationMuted = xerr.NewMsg("无效的免打扰截止时间")
+	ErrConversationName  = xerr.NewMsg("会话名称过长")
+)
+
 type PutConversationsLogic struct {
 	ctx    context.Context
 	svcCtx *svc.ServiceContext
@@ -31,6 +40,8 @@ func NewPutConversationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 //
 // 该方法更新指定用户的会话列表，将新的会话信息保存到数据库中。
 // 如果用户原本有会话数据，将会合并新数据；如果没有，将会创建新的会话记录。
+// 会话的置顶、免打扰、归档与自定义名称等个人设置随会话一并保存。
+// 请求中的已读序号只会推进用户的已读位置，不会使其回退；会话按字段更新，清空位置与删除标记等保持不变。
 //
 // 参数:
 //   - in: 请求对象，包含需要更新的会话信息。
@@ -46,31 +57,39 @@ func (l *PutConversationsLogic) PutConversations(in *im.PutConversationsReq) (*i
 		return nil, errors.Wrapf(xerr.NewDBErr(), "find conversations by user id failed, uid: %s, err: %v", in.UserId, err)
 	}
 
-	// 如果会话列表为空，则初始化一个空的会话列表
+	// 如果会话列表为空，则初始化一个空的会话列表，以便按字段更新其中的会话
 	if data.ConversationList == nil {
 		data.ConversationList = make(map[string]*immodels.Conversation)
+		if _, err := l.svcCtx.ConversationsModel.Update(l.ctx, data); err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "init conversations failed, uid: %s, err: %v", in.UserId, err)
+		}
 	}
 
+	conversations := make(map[string]*immodels.Conversation, len(in.ConversationList))
 	for s, conversation := range in.ConversationList {
-		// 获取用户原本读取的会话消息量
-		var oldTotal int
-		if data.ConversationList[s] != nil {
-			oldTotal = data.ConversationList[s].Total
+		if err := validSettings(conversation); err != nil {
+			return nil, err
 		}
 
-		// 更新会话信息
-		data.ConversationList[s] = &immodels.Conversation{
+		conversations[s] = &immodels.Conversation{
 			ConversationId: conversation.ConversationId,
 			ChatType:       constants.ChatType(conversation.ChatType),
 			IsShow:         conversation.IsShow,
-			Total:          int(conversation.Read) + oldTotal, // 新的已读记录量 + 原本读取的会话消息量
+			Total:          int(conversation.Read), // 新的已读记录量，累加到原本读取的会话消息量上
 			Seq:            conversation.Seq,
+			Pinned:         conversation.Pinned,
+			MutedUntil:     conversation.MutedUntil,
+			Archived:       conversation.Archived,
+			DisplayName:    conversation.DisplayName,
+			ReadSeq:        conversation.ReadSeq, // 已读序号只增不减
+		}
+		if conversation.Pinned {
+			conversations[s].PinOrder = conversation.PinOrder
 		}
 	}
 
-	// 将更新后的会话列表保存到数据库
-	_, err = l.svcCtx.ConversationsModel.Update(l.ctx, data)
-	if err != nil {
+	// 按字段更新会话，保留清空位置、删除标记等未提交的字段，避免覆盖并发的更新
+	if err := l.svcCtx.ConversationsModel.PutConversations(l.ctx, in.UserId, conversations); err != nil {
 		// 更新会话列表失败，返回 nil 和错误信息
 		return nil, errors.Wrapf(xerr.NewDBErr(), "update conversations failed, uid: %s, err: %v", in.UserId, err)
 	}
@@ -78,3 +97,14 @@ func (l *PutConversationsLogic) PutConversations(in *im.PutConversationsReq) (*i
 	// 更新成功，返回空响应和 nil 错误
 	return &im.PutConversationsResp{}, nil
 }
+
+// validSettings 校验会话的个人设置。
+func validSettings(conversation *im.Conversation) error {
+	if conversation.MutedUntil < 0 && conversation.MutedUntil != immodels.MutedForever {
+		return errors.WithStack(ErrConversationMuted)
+	}
+	if utf8.RuneCountInString(conversation.DisplayName) > maxDisplayNameLen {
+		return errors.WithStack(ErrConversationName)
+	}
+	return nil
+}
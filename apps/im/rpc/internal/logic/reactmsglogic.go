@@ -0,0 +1,140 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"strings"
+	"unicode/utf8"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// maxEmojiLen 表情的最大字节数，组合表情由多个码点组成
+const maxEmojiLen = 32
+
+var (
+	ErrReactNotAllowed = xerr.NewMsg("只能回应所在会话中的消息")
+	ErrReactRecalled   = xerr.NewMsg("消息已撤回")
+	ErrReactEmoji      = xerr.NewMsg("无效的表情")
+)
+
+type ReactMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewReactMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReactMsgLogic {
+	return &ReactMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ReactMsg 添加或移除表情回应。
+//
+// 单聊的双方与群聊的成员可以对会话中的任意消息回应表情，已撤回的消息不能回应。
+// 回应的结果直接保存在聊天记录上，并通过消息队列发布回应变更通知，通知中携带消息更新后的全部回应，
+// 由 task 服务推送给会话中的其他成员，群聊中短时间内的多次变更会被合并推送。
+//
+// 参数:
+//   - in: 请求对象，包含消息ID、执行回应的用户ID、表情与是否移除。
+//
+// 返回值:
+//   - *im.ReactMsgResp: 更新后的全部表情回应。
+//   - error: 没有权限、表情无效或操作失败时返回错误。
+func (l *ReactMsgLogic) ReactMsg(in *im.ReactMsgReq) (*im.ReactMsgResp, error) {
+	if !validEmoji(in.Emoji) {
+		return nil, errors.WithStack(ErrReactEmoji)
+	}
+
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err: %v, req: %v", err, in)
+	}
+	if chatLog.Status == constants.RecalledMsgStatus {
+		return nil, errors.WithStack(ErrReactRecalled)
+	}
+	if err := l.checkMember(in.UserId, chatLog.SendId, chatLog.RecvId, chatLog.ChatType); err != nil {
+		return nil, err
+	}
+
+	// 更新表情回应
+	chatLog, err = l.svcCtx.ChatLogModel.UpdateReaction(l.ctx, chatLog.ID, in.Emoji, in.UserId, !in.Remove)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "update chatlog reaction err: %v, req: %v", err, in)
+	}
+
+	// 发布回应变更通知
+	err = l.svcCtx.MsgNoticeTransferClient.Push(&mq.MsgNotice{
+		ContentType:    constants.ContentReaction,
+		ChatType:       chatLog.ChatType,
+		ConversationId: chatLog.ConversationId,
+		SendId:         in.UserId,
+		RecvId:         reactionRecvId(in.UserId, chatLog),
+		MsgId:          in.MsgId,
+		Seq:            chatLog.Seq,
+		Reactions:      chatLog.Reactions,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "push reaction notice err, req: %v", in)
+	}
+
+	return &im.ReactMsgResp{
+		Reactions: toReactions(chatLog.Reactions),
+	}, nil
+}
+
+// checkMember 校验用户是否为消息所在会话的成员。
+func (l *ReactMsgLogic) checkMember(uid, sendId, recvId string, chatType constants.ChatType) error {
+	if uid == sendId {
+		return nil
+	}
+
+	switch chatType {
+	case constants.SingleChatType:
+		if uid == recvId {
+			return nil
+		}
+	case constants.GroupChatType:
+		users, err := l.svcCtx.Social.GroupUsers(l.ctx, &socialclient.GroupUsersReq{
+			GroupId: recvId,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "get group users err, groupId: %v", recvId)
+		}
+		for _, user := range users.List {
+			if user.UserId == uid {
+				return nil
+			}
+		}
+	}
+	return errors.WithStack(ErrReactNotAllowed)
+}
+
+// reactionRecvId 返回回应变更通知的接收者。
+//
+// 单聊中为回应者之外的另一方：接收者回应收到的消息时通知消息的发送者；群聊中为群ID。
+func reactionRecvId(uid string, chatLog *immodels.ChatLog) string {
+	if chatLog.ChatType == constants.SingleChatType && uid == chatLog.RecvId {
+		return chatLog.SendId
+	}
+	return chatLog.RecvId
+}
+
+// validEmoji 校验表情，表情会作为文档字段名保存，不能包含 "." 或以 "$" 开头。
+func validEmoji(emoji string) bool {
+	if emoji == "" || len(emoji) > maxEmojiLen || !utf8.ValidString(emoji) {
+		return false
+	}
+	return !strings.Contains(emoji, ".") && !strings.HasPrefix(emoji, "$")
+}
@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
+	"testing"
+)
+
+func TestReactionRecvId(t *testing.T) {
+	single := &immodels.ChatLog{ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2"}
+	group := &immodels.ChatLog{ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1"}
+
+	tests := []struct {
+		name    string
+		uid     string
+		chatLog *immodels.ChatLog
+		want    string
+	}{
+		{"发送者回应，通知接收者", "u1", single, "u2"},
+		{"接收者回应，通知发送者", "u2", single, "u1"},
+		{"群聊通知群成员", "u3", group, "g1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reactionRecvId(tt.uid, tt.chatLog); got != tt.want {
+				t.Errorf("reactionRecvId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
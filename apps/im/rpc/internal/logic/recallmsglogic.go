@@ -0,0 +1,121 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrRecallNotAllowed = xerr.NewMsg("没有权限撤回该消息")
+	ErrRecallTimeout    = xerr.NewMsg("消息已超过可撤回的时间")
+)
+
+type RecallMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRecallMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RecallMsgLogic {
+	return &RecallMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// RecallMsg 撤回消息。
+//
+// 消息的发送者可以在 MsgRecallWindow 时间内撤回自己的消息；群聊中，群主与管理员可以撤回任意成员的消息。
+// 撤回后更新聊天记录的状态，并通过消息队列发布撤回通知，由 task 服务推送给会话中的其他成员。
+// 重复撤回同一条消息不会返回错误。
+//
+// 参数:
+//   - in: 请求对象，包含消息ID与执行撤回的用户ID。
+//
+// 返回值:
+//   - *im.RecallMsgResp: 撤回结果的响应对象。
+//   - error: 没有权限、超过撤回时间或操作失败时返回错误。
+func (l *RecallMsgLogic) RecallMsg(in *im.RecallMsgReq) (*im.RecallMsgResp, error) {
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err: %v, req: %v", err, in)
+	}
+	// 已经撤回
+	if chatLog.Status == constants.RecalledMsgStatus {
+		return &im.RecallMsgResp{}, nil
+	}
+
+	// 校验撤回权限
+	if err := l.checkRecall(in.UserId, chatLog.SendId, chatLog.RecvId, chatLog.ChatType, chatLog.SendTime); err != nil {
+		return nil, err
+	}
+
+	// 更新消息状态
+	chatLog.Status = constants.RecalledMsgStatus
+	if err := l.svcCtx.ChatLogModel.UpdateStatus(l.ctx, chatLog.ID, chatLog.Status); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "update chatlog status err: %v, req: %v", err, in)
+	}
+	if err := l.svcCtx.ConversationModel.UpdateLastMsg(l.ctx, chatLog); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "update conversation msg status err: %v, req: %v", err, in)
+	}
+
+	// 发布撤回通知
+	err = l.svcCtx.MsgNoticeTransferClient.Push(&mq.MsgNotice{
+		ContentType:    constants.ContentRecall,
+		ChatType:       chatLog.ChatType,
+		ConversationId: chatLog.ConversationId,
+		SendId:         in.UserId,
+		RecvId:         chatLog.RecvId,
+		MsgId:          in.MsgId,
+		Seq:            chatLog.Seq,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "push recall notice err, req: %v", in)
+	}
+
+	return &im.RecallMsgResp{}, nil
+}
+
+// checkRecall 校验用户是否可以撤回消息。
+//
+// 发送者在撤回时间窗口内可以撤回；群聊中群主与管理员可以撤回任意成员的消息。
+func (l *RecallMsgLogic) checkRecall(uid, sendId, recvId string, chatType constants.ChatType, sendTime int64) error {
+	if uid == sendId && time.Since(time.UnixMilli(sendTime)) <= l.svcCtx.Config.MsgRecallWindow {
+		return nil
+	}
+
+	if chatType == constants.GroupChatType {
+		users, err := l.svcCtx.Social.GroupUsers(l.ctx, &socialclient.GroupUsersReq{
+			GroupId: recvId,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "get group users err, groupId: %v", recvId)
+		}
+		for _, user := range users.List {
+			if user.UserId != uid {
+				continue
+			}
+			switch constants.GroupRoleLevel(user.RoleLevel) {
+			case constants.CreatorGroupRoleLevel, constants.ManagerGroupRoleLevel:
+				return nil
+			}
+			break
+		}
+	}
+
+	if uid == sendId {
+		return errors.WithStack(ErrRecallTimeout)
+	}
+	return errors.WithStack(ErrRecallNotAllowed)
+}
@@ -0,0 +1,309 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+	"strings"
+	"unicode"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// maxSearchScans 单次搜索最多查询数据库的次数
+	maxSearchScans = 5
+	// snippetLen 摘要的最大字符数
+	snippetLen = 60
+	// snippetBefore 摘要中保留的第一个命中位置之前的字符数
+	snippetBefore = 15
+	// snippetEllipsis 摘要被截断时使用的省略号
+	snippetEllipsis = "…"
+)
+
+var (
+	ErrSearchKeyword      = xerr.NewMsg("搜索关键词不能为空")
+	ErrSearchConversation = xerr.NewMsg("只能搜索所在会话的聊天记录")
+	ErrSearchCursor       = xerr.NewMsg("无效的分页游标")
+)
+
+type SearchChatLogLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSearchChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchChatLogLogic {
+	return &SearchChatLogLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// SearchChatLog 在用户的会话中全文搜索聊天记录。
+//
+// 搜索范围限定为用户会话列表中的会话，其中群聊会话要求用户当前仍是群成员，用户已清空的消息不参与搜索。
+// 关键词按空白分隔为多个词，消息内容、附件文件名或地点中需包含全部的词；先通过聊天记录上的倒排索引筛选候选消息，
+// 再逐条校验是否确实包含关键词，并生成带高亮区间的摘要。结果按消息从新到旧排列，使用 nextCursor 继续拉取下一页。
+//
+// 参数:
+//   - in: 请求对象，包含用户ID、关键词、可选的会话、发送者、时间范围、消息类型过滤条件以及分页参数。
+//
+// 返回值:
+//   - *im.SearchChatLogResp: 命中的聊天记录与摘要、下一页游标以及是否还有更多结果。
+//   - error: 关键词为空、会话无权访问、游标无效或查询失败时返回错误。
+func (l *SearchChatLogLogic) SearchChatLog(in *im.SearchChatLogReq) (*im.SearchChatLogResp, error) {
+	terms := strings.Fields(strings.ToLower(in.Keyword))
+	tokens := immodels.SearchTokens(in.Keyword, true)
+	if len(terms) == 0 || len(tokens) == 0 {
+		return nil, errors.WithStack(ErrSearchKeyword)
+	}
+
+	limit := in.Limit
+	if limit <= 0 || limit > immodels.DefaultChatLogLimit {
+		limit = immodels.DefaultChatLogLimit
+	}
+
+	conversationIds, cleared, err := l.conversationIds(in.UserId, in.ConversationId)
+	if err != nil {
+		return nil, err
+	}
+	if len(conversationIds) == 0 {
+		return &im.SearchChatLogResp{}, nil
+	}
+
+	search := &immodels.ChatLogSearch{
+		ConversationIds: conversationIds,
+		ClearedSeqs:     cleared,
+		Tokens:          tokens,
+		SendId:          in.SendId,
+		StartTime:       in.StartTime,
+		EndTime:         in.EndTime,
+	}
+	for _, t := range in.MsgTypes {
+		search.MsgTypes = append(search.MsgTypes, constants.MType(t))
+	}
+	if in.Cursor != "" {
+		if search.Before, err = primitive.ObjectIDFromHex(in.Cursor); err != nil {
+			return nil, errors.WithStack(ErrSearchCursor)
+		}
+	}
+
+	// 倒排索引只能筛选出包含全部索引词的候选消息，逐条校验后可能不足一页，此时继续向后查询
+	var (
+		hits    = make([]*im.SearchChatLogHit, 0, limit)
+		hasMore bool
+	)
+	for i := 0; i < maxSearchScans && int64(len(hits)) < limit; i++ {
+		data, err := l.svcCtx.ChatLogModel.Search(l.ctx, search, limit)
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "search chatLog failed, err: %v req: %v", err.Error(), in)
+		}
+
+		hasMore = int64(len(data)) == limit
+		for j, v := range data {
+			search.Before = v.ID
+			if hit := matchChatLog(v, terms); hit != nil {
+				hits = append(hits, hit)
+			}
+			if int64(len(hits)) == limit {
+				hasMore = hasMore || j < len(data)-1
+				break
+			}
+		}
+		if !hasMore {
+			break
+		}
+	}
+
+	list := make([]*im.ChatLog, 0, len(hits))
+	for _, hit := range hits {
+		list = append(list, hit.ChatLog)
+	}
+	if err := attachQuotes(l.ctx, l.svcCtx.ChatLogModel, list); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find quoted chatLog failed, err: %v req: %v", err.Error(), in)
+	}
+
+	res := &im.SearchChatLogResp{
+		List:    hits,
+		HasMore: hasMore,
+	}
+	if hasMore {
+		res.NextCursor = search.Before.Hex()
+	}
+	return res, nil
+}
+
+// conversationIds 获取用户可以搜索的会话ID。
+//
+// 参数:
+//   - uid: 用户ID。
+//   - conversationId: 指定搜索的会话ID，为空时返回用户的全部会话。
+//
+// 返回值:
+//   - []string: 可以搜索的会话ID。
+//   - map[string]int64: 用户清空过聊天记录的会话及其清空位置。
+//   - error: 指定的会话无权访问或查询失败时返回错误。
+func (l *SearchChatLogLogic) conversationIds(uid, conversationId string) ([]string, map[string]int64, error) {
+	data, err := l.svcCtx.ConversationsModel.FindByUserId(l.ctx, uid)
+	if err != nil && !errors.Is(err, immodels.ErrNotFound) {
+		return nil, nil, errors.Wrapf(xerr.NewDBErr(), "find conversations by user id failed, uid: %s, err: %v", uid, err)
+	}
+
+	var conversations map[string]*immodels.Conversation
+	if data != nil {
+		conversations = data.ConversationList
+	}
+
+	// 群聊会话需要校验用户当前仍是群成员，退群后不能再搜索该群的聊天记录
+	var groups map[string]struct{}
+	for _, conversation := range conversations {
+		if conversation.ChatType != constants.GroupChatType {
+			continue
+		}
+		groupList, err := l.svcCtx.Social.GroupList(l.ctx, &socialclient.GroupListReq{
+			UserId: uid,
+		})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "get group list err, uid: %v", uid)
+		}
+		groups = make(map[string]struct{}, len(groupList.List))
+		for _, group := range groupList.List {
+			groups[group.Id] = struct{}{}
+		}
+		break
+	}
+
+	ids := make([]string, 0, len(conversations))
+	cleared := make(map[string]int64)
+	for _, conversation := range conversations {
+		if conversation.ChatType == constants.GroupChatType {
+			if _, ok := groups[conversation.ConversationId]; !ok {
+				continue
+			}
+		}
+		if conversationId != "" && conversation.ConversationId != conversationId {
+			continue
+		}
+		ids = append(ids, conversation.ConversationId)
+		if conversation.ClearedSeq > 0 {
+			cleared[conversation.ConversationId] = conversation.ClearedSeq
+		}
+	}
+
+	if conversationId != "" && len(ids) == 0 {
+		return nil, nil, errors.WithStack(ErrSearchConversation)
+	}
+	return ids, cleared, nil
+}
+
+// matchChatLog 校验聊天记录是否包含全部的词，包含时生成带高亮的搜索结果，否则返回 nil。
+//
+// 优先在消息内容中生成摘要，消息内容不包含全部的词时使用附件的文件名或地点。
+func matchChatLog(v *immodels.ChatLog, terms []string) *im.SearchChatLogHit {
+	texts := []string{v.MsgContent}
+	if p := v.Payload; p != nil {
+		texts = append(texts, p.Name, p.Address, strings.Join([]string{v.MsgContent, p.Name, p.Address}, " "))
+	}
+
+	for _, text := range texts {
+		snippet, highlights, ok := highlight(text, terms)
+		if !ok {
+			continue
+		}
+		return &im.SearchChatLogHit{
+			ChatLog:    toChatLog(v, false),
+			Snippet:    snippet,
+			Highlights: highlights,
+		}
+	}
+	return nil
+}
+
+// highlight 在文本中查找全部的词，生成以第一个命中位置为中心的摘要与摘要中的高亮区间。
+//
+// 参数:
+//   - text: 要查找的文本。
+//   - terms: 小写的搜索词。
+//
+// 返回值:
+//   - string: 摘要，超出长度的部分以省略号代替。
+//   - []*im.Highlight: 摘要中以字符计的高亮区间，按起始位置排列且互不重叠。
+//   - bool: 文本是否包含全部的词。
+func highlight(text string, terms []string) (string, []*im.Highlight, bool) {
+	src := []rune(text)
+	// 逐个字符转为小写，保证与原文的字符位置一一对应
+	lower := make([]rune, len(src))
+	for i, r := range src {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var ranges [][2]int
+	for _, term := range terms {
+		t := []rune(term)
+		found := false
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == term {
+				ranges = append(ranges, [2]int{i, i + len(t)})
+				found = true
+				i += len(t) - 1
+			}
+		}
+		if !found {
+			return "", nil, false
+		}
+	}
+
+	// 合并重叠的区间
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r[0] <= last[1] {
+			last[1] = max(last[1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	// 截取摘要
+	start := max(merged[0][0]-snippetBefore, 0)
+	end := min(start+snippetLen, len(src))
+	if end-start < snippetLen {
+		start = max(end-snippetLen, 0)
+	}
+
+	var (
+		snippet strings.Builder
+		offset  = -start
+	)
+	if start > 0 {
+		snippet.WriteString(snippetEllipsis)
+		offset++
+	}
+	snippet.WriteString(string(src[start:end]))
+	if end < len(src) {
+		snippet.WriteString(snippetEllipsis)
+	}
+
+	highlights := make([]*im.Highlight, 0, len(merged))
+	for _, r := range merged {
+		if r[0] >= end {
+			break
+		}
+		highlights = append(highlights, &im.Highlight{
+			Start: int32(max(r[0], start) + offset),
+			End:   int32(min(r[1], end) + offset),
+		})
+	}
+	return snippet.String(), highlights, true
+}
@@ -0,0 +1,74 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncMessagesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSyncMessagesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncMessagesLogic {
+	return &SyncMessagesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// SyncMessages 按序号同步会话中缺失的消息。
+//
+// 客户端重连后携带本地已收到的最大序号 fromSeq，该方法按序号升序返回会话中 fromSeq 之后的消息，
+// 客户端可根据 hasMore 继续以最后一条消息的序号分页拉取，直到补齐离线期间的全部消息。
+//
+// 参数:
+//   - in: 请求对象，包含会话ID、起始序号与拉取数量。
+//
+// 返回值:
+//   - *im.SyncMessagesResp: 同步结果，包含消息列表与是否还有更多消息。
+//   - error: 查询失败时返回错误。
+func (l *SyncMessagesLogic) SyncMessages(in *im.SyncMessagesReq) (*im.SyncMessagesResp, error) {
+	limit := in.Limit
+	if limit <= 0 || limit > immodels.DefaultChatLogLimit {
+		limit = immodels.DefaultChatLogLimit
+	}
+
+	// 用户已清空的消息不再同步
+	cleared, err := clearedSeq(l.ctx, l.svcCtx.ConversationsModel, in.UserId, in.ConversationId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find cleared seq failed, err: %v req: %v", err, in)
+	}
+
+	// 多查询一条用于判断是否还有更多消息
+	data, err := l.svcCtx.ChatLogModel.ListBySeq(l.ctx, in.ConversationId, max(in.FromSeq, cleared), limit+1)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatLog list by seq failed, err: %v req: %v", err.Error(), in)
+	}
+
+	hasMore := int64(len(data)) > limit
+	if hasMore {
+		data = data[:limit]
+	}
+
+	res := make([]*im.ChatLog, 0, len(data))
+	for _, v := range data {
+		res = append(res, toChatLog(v, false))
+	}
+	if err := attachQuotes(l.ctx, l.svcCtx.ChatLogModel, res); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find quoted chatLog failed, err: %v req: %v", err.Error(), in)
+	}
+	return &im.SyncMessagesResp{
+		List:    res,
+		HasMore: hasMore,
+	}, nil
+}
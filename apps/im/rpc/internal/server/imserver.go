@@ -28,6 +28,42 @@ func (s *ImServer) GetChatLog(ctx context.Context, in *im.GetChatLogReq) (*im.Ge
 	return l.GetChatLog(in)
 }
 
+// 按序号同步会话中缺失的消息
+func (s *ImServer) SyncMessages(ctx context.Context, in *im.SyncMessagesReq) (*im.SyncMessagesResp, error) {
+	l := logic.NewSyncMessagesLogic(ctx, s.svcCtx)
+	return l.SyncMessages(in)
+}
+
+// 搜索聊天记录
+func (s *ImServer) SearchChatLog(ctx context.Context, in *im.SearchChatLogReq) (*im.SearchChatLogResp, error) {
+	l := logic.NewSearchChatLogLogic(ctx, s.svcCtx)
+	return l.SearchChatLog(in)
+}
+
+// 获取消息的已读用户
+func (s *ImServer) GetReadRecords(ctx context.Context, in *im.GetReadRecordsReq) (*im.GetReadRecordsResp, error) {
+	l := logic.NewGetReadRecordsLogic(ctx, s.svcCtx)
+	return l.GetReadRecords(in)
+}
+
+// 撤回消息
+func (s *ImServer) RecallMsg(ctx context.Context, in *im.RecallMsgReq) (*im.RecallMsgResp, error) {
+	l := logic.NewRecallMsgLogic(ctx, s.svcCtx)
+	return l.RecallMsg(in)
+}
+
+// 编辑消息
+func (s *ImServer) EditMsg(ctx context.Context, in *im.EditMsgReq) (*im.EditMsgResp, error) {
+	l := logic.NewEditMsgLogic(ctx, s.svcCtx)
+	return l.EditMsg(in)
+}
+
+// 添加或移除表情回应
+func (s *ImServer) ReactMsg(ctx context.Context, in *im.ReactMsgReq) (*im.ReactMsgResp, error) {
+	l := logic.NewReactMsgLogic(ctx, s.svcCtx)
+	return l.ReactMsg(in)
+}
+
 // 建立会话: 群聊, 私聊
 func (s *ImServer) SetUpUserConversation(ctx context.Context, in *im.SetUpUserConversationReq) (*im.SetUpUserConversationResp, error) {
 	l := logic.NewSetUpUserConversationLogic(ctx, s.svcCtx)
@@ -46,6 +82,18 @@ func (s *ImServer) PutConversations(ctx context.Context, in *im.PutConversations
 	return l.PutConversations(in)
 }
 
+// 清空会话的聊天记录，只对当前用户生效
+func (s *ImServer) ClearConversation(ctx context.Context, in *im.ClearConversationReq) (*im.ClearConversationResp, error) {
+	l := logic.NewClearConversationLogic(ctx, s.svcCtx)
+	return l.ClearConversation(in)
+}
+
+// 删除会话，会话中有新消息时重新显示
+func (s *ImServer) DeleteConversation(ctx context.Context, in *im.DeleteConversationReq) (*im.DeleteConversationResp, error) {
+	l := logic.NewDeleteConversationLogic(ctx, s.svcCtx)
+	return l.DeleteConversation(in)
+}
+
 func (s *ImServer) CreateGroupConversation(ctx context.Context, in *im.CreateGroupConversationReq) (*im.CreateGroupConversationResp, error) {
 	l := logic.NewCreateGroupConversationLogic(ctx, s.svcCtx)
 	return l.CreateGroupConversation(in)
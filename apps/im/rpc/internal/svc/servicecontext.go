@@ -3,6 +3,9 @@ package svc
 import (
 	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/rpc/internal/config"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/mqclient"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
@@ -11,13 +14,18 @@ type ServiceContext struct {
 	immodels.ChatLogModel
 	immodels.ConversationsModel
 	immodels.ConversationModel
+
+	socialclient.Social
+	mqclient.MsgNoticeTransferClient
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	return &ServiceContext{
-		Config:             c,
-		ChatLogModel:       immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
-		ConversationsModel: immodels.MustConversationsModel(c.Mongo.Url, c.Mongo.Db),
-		ConversationModel:  immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
+		Config:                  c,
+		ChatLogModel:            immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
+		ConversationsModel:      immodels.MustConversationsModel(c.Mongo.Url, c.Mongo.Db),
+		ConversationModel:       immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
+		Social:                  socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
+		MsgNoticeTransferClient: mqclient.NewMsgNoticeTransferClient(c.MsgNoticeTransfer.Addrs, c.MsgNoticeTransfer.Topic),
 	}
 }
@@ -0,0 +1,151 @@
+package gateway
+
+import (
+	"context"
+	"easy-chat/pkg/constants"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"sync"
+	"time"
+)
+
+// DefaultRouteTTL 用户路由记录的默认过期时间。
+//
+// im-ws 节点在设备连接时登记路由，并在心跳中周期性地重新登记，刷新间隔需明显小于过期时间；
+// 节点异常退出后不再刷新，其路由随之过期。
+const DefaultRouteTTL = 90 * time.Second
+
+// unregisterScript 仅当设备仍然登记在指定节点时才删除路由，
+// 避免设备已经迁移到其他节点后，旧节点的断开事件删除新的路由记录。
+var unregisterScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// Registry 连接注册表，记录用户的每个设备连接在哪个网关节点上。
+//
+// im-ws 节点在设备连接建立与断开时登记/注销路由，task 服务在推送消息前根据接收者
+// 查询其所在的节点，只向持有接收者连接的节点投递消息。
+type Registry interface {
+	// Register 登记用户设备连接所在的节点。
+	Register(ctx context.Context, node, uid, deviceId string) error
+	// Unregister 注销用户设备在指定节点上的连接。
+	Unregister(ctx context.Context, node, uid, deviceId string) error
+	// Lookup 查询用户所在的节点，返回 节点 -> 该节点上的用户ID列表。
+	Lookup(ctx context.Context, uids ...string) (map[string][]string, error)
+}
+
+// redisRegistry 基于 Redis 的连接注册表。
+//
+// 每个用户对应一个 hash，键为 RedisWsRoute + 用户ID，字段为设备ID，值为节点ID。
+// 每次登记都会刷新 hash 的过期时间。
+type redisRegistry struct {
+	*redis.Redis
+	ttl time.Duration
+}
+
+// NewRedisRegistry 创建一个基于 Redis 的连接注册表。
+//
+// 参数:
+//   - rds: Redis 客户端，im-ws 与 task 服务需使用同一个 Redis。
+//   - ttl: 路由记录的过期时间，小于等于 0 时使用 DefaultRouteTTL；
+//     im-ws 节点需在过期前重新登记仍然在线的连接，通常取心跳间隔的数倍。
+//
+// 返回值:
+//   - Registry: 连接注册表实例。
+func NewRedisRegistry(rds *redis.Redis, ttl time.Duration) Registry {
+	if ttl <= 0 {
+		ttl = DefaultRouteTTL
+	}
+	return &redisRegistry{
+		Redis: rds,
+		ttl:   ttl,
+	}
+}
+
+func (r *redisRegistry) Register(ctx context.Context, node, uid, deviceId string) error {
+	key := constants.RedisWsRoute + uid
+	if err := r.HsetCtx(ctx, key, deviceId, node); err != nil {
+		return err
+	}
+	return r.ExpireCtx(ctx, key, int(max(r.ttl/time.Second, 1)))
+}
+
+func (r *redisRegistry) Unregister(ctx context.Context, node, uid, deviceId string) error {
+	_, err := r.ScriptRunCtx(ctx, unregisterScript, []string{constants.RedisWsRoute + uid}, deviceId, node)
+	return err
+}
+
+func (r *redisRegistry) Lookup(ctx context.Context, uids ...string) (map[string][]string, error) {
+	res := make(map[string][]string)
+	for _, uid := range uids {
+		devices, err := r.HgetallCtx(ctx, constants.RedisWsRoute+uid)
+		if err != nil {
+			return nil, err
+		}
+		appendNodes(res, uid, devices)
+	}
+	return res, nil
+}
+
+// memoryRegistry 基于内存的连接注册表，用于单进程部署与测试。
+type memoryRegistry struct {
+	mu     sync.RWMutex
+	routes map[string]map[string]string // uid -> deviceId -> node
+}
+
+// NewMemoryRegistry 创建一个基于内存的连接注册表。
+func NewMemoryRegistry() Registry {
+	return &memoryRegistry{
+		routes: make(map[string]map[string]string),
+	}
+}
+
+func (r *memoryRegistry) Register(ctx context.Context, node, uid, deviceId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.routes[uid] == nil {
+		r.routes[uid] = make(map[string]string)
+	}
+	r.routes[uid][deviceId] = node
+	return nil
+}
+
+func (r *memoryRegistry) Unregister(ctx context.Context, node, uid, deviceId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.routes[uid][deviceId] != node {
+		return nil
+	}
+	delete(r.routes[uid], deviceId)
+	if len(r.routes[uid]) == 0 {
+		delete(r.routes, uid)
+	}
+	return nil
+}
+
+func (r *memoryRegistry) Lookup(ctx context.Context, uids ...string) (map[string][]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make(map[string][]string)
+	for _, uid := range uids {
+		appendNodes(res, uid, r.routes[uid])
+	}
+	return res, nil
+}
+
+// appendNodes 将用户各设备所在的节点合并到结果中，同一节点上的多个设备只记录一次用户。
+func appendNodes(res map[string][]string, uid string, devices map[string]string) {
+	seen := make(map[string]struct{}, len(devices))
+	for _, node := range devices {
+		if _, ok := seen[node]; ok {
+			continue
+		}
+		seen[node] = struct{}{}
+		res[node] = append(res[node], uid)
+	}
+}
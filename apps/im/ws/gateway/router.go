@@ -0,0 +1,111 @@
+package gateway
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+	"os"
+	"strings"
+)
+
+// Router 跨节点的消息路由。
+//
+// 根据连接注册表查询接收者所在的网关节点，并且只向持有接收者连接的节点投递消息，
+// 每个节点收到的推送中只包含该节点上的接收者。
+type Router struct {
+	Registry
+	Transport
+}
+
+// NewRouter 创建一个跨节点消息路由。
+//
+// 参数:
+//   - registry: 连接注册表。
+//   - transport: 节点间的消息路由层。
+//
+// 返回值:
+//   - *Router: 消息路由实例。
+func NewRouter(registry Registry, transport Transport) *Router {
+	return &Router{
+		Registry:  registry,
+		Transport: transport,
+	}
+}
+
+// Deliver 将推送消息投递到接收者所在的网关节点。
+//
+// 单聊消息的接收者为 RecvId，群聊消息的接收者为 RecvIds。
+// 接收者全部离线时不进行投递；群聊消息投递到各节点时，RecvIds 只保留该节点上的接收者。
+// 某个节点投递失败不会影响其他节点，最终返回最后一次出现的错误。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - push: 待推送的消息。
+//
+// 返回值:
+//   - error: 投递过程中出现的错误。
+func (r *Router) Deliver(ctx context.Context, push *ws.Push) error {
+	return r.deliver(ctx, push, "")
+}
+
+// DeliverRemote 将推送消息投递到接收者所在的其他网关节点，跳过 self 节点。
+//
+// 用于网关节点自己产生的消息：本节点上的接收者由调用方直接投递，其余节点通过路由层转发。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - push: 待推送的消息。
+//   - self: 当前节点ID。
+//
+// 返回值:
+//   - error: 投递过程中出现的错误。
+func (r *Router) DeliverRemote(ctx context.Context, push *ws.Push, self string) error {
+	return r.deliver(ctx, push, self)
+}
+
+// deliver 按接收者所在节点投递推送消息，skip 不为空时跳过该节点。
+func (r *Router) deliver(ctx context.Context, push *ws.Push, skip string) error {
+	uids := push.RecvIds
+	if push.ChatType == constants.SingleChatType {
+		uids = []string{push.RecvId}
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+
+	nodes, err := r.Lookup(ctx, uids...)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for node, nodeUids := range nodes {
+		if node == skip {
+			continue
+		}
+		data := *push
+		if push.ChatType != constants.SingleChatType {
+			data.RecvIds = nodeUids
+		}
+		if err := r.Publish(ctx, node, &data); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// DefaultNodeId 生成默认的节点ID：主机名 + 监听端口。
+//
+// 参数:
+//   - listenOn: 服务监听地址，例如 "0.0.0.0:10090"。
+//
+// 返回值:
+//   - string: 节点ID，例如 "im-ws-0-10090"。
+func DefaultNodeId(listenOn string) string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	port := listenOn[strings.LastIndex(listenOn, ":")+1:]
+	return host + "-" + port
+}
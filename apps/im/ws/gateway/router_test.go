@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestRouter_Deliver(t *testing.T) {
+	registry := NewMemoryRegistry()
+	ctx := context.Background()
+	_ = registry.Register(ctx, "node-a", "u1", "ios")
+	_ = registry.Register(ctx, "node-b", "u1", "pc")
+	_ = registry.Register(ctx, "node-a", "u2", "ios")
+	_ = registry.Register(ctx, "node-b", "u3", "web")
+	// u3 的 web 设备已迁移到 node-b，node-a 的断开事件不应删除新的路由
+	_ = registry.Unregister(ctx, "node-a", "u3", "web")
+
+	tests := []struct {
+		name string
+		push *ws.Push
+		want map[string][]string // 节点 -> 收到的接收者
+	}{
+		{
+			"单聊投递到所有设备所在节点",
+			&ws.Push{ChatType: constants.SingleChatType, RecvId: "u1"},
+			map[string][]string{"node-a": {"u1"}, "node-b": {"u1"}},
+		},
+		{
+			"单聊接收者离线",
+			&ws.Push{ChatType: constants.SingleChatType, RecvId: "offline"},
+			map[string][]string{},
+		},
+		{
+			"群聊按节点拆分接收者",
+			&ws.Push{ChatType: constants.GroupChatType, RecvId: "g1", RecvIds: []string{"u1", "u2", "u3", "offline"}},
+			map[string][]string{"node-a": {"u1", "u2"}, "node-b": {"u1", "u3"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			got := make(map[string][]string)
+
+			transport := NewMemoryTransport()
+			for _, node := range []string{"node-a", "node-b"} {
+				node := node
+				transport.Subscribe(node, func(ctx context.Context, push *ws.Push) error {
+					mu.Lock()
+					defer mu.Unlock()
+					if push.ChatType == constants.SingleChatType {
+						got[node] = append(got[node], push.RecvId)
+					} else {
+						got[node] = append(got[node], push.RecvIds...)
+					}
+					return nil
+				})
+			}
+
+			if err := NewRouter(registry, transport).Deliver(ctx, tt.push); err != nil {
+				t.Fatalf("Deliver() err = %v", err)
+			}
+			for _, uids := range got {
+				sort.Strings(uids)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Deliver() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
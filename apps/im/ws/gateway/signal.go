@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/ws"
+	"encoding/json"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"strings"
+)
+
+// RedisTransport 基于 Redis 发布订阅的节点路由，每个节点订阅一个独立的频道。
+//
+// 与 kafka 路由不同，消息不落盘、不重试，节点不在线时消息直接丢弃，
+// 适用于输入状态等时效很短、丢失也无影响的临时信令。
+type RedisTransport struct {
+	client  goredis.UniversalClient
+	channel string
+}
+
+// NewRedisTransport 创建一个基于 Redis 发布订阅的节点路由。
+//
+// 参数:
+//   - c: Redis 配置，各 im-ws 节点需使用同一个 Redis。
+//   - channel: 频道前缀，节点频道由 NodeTopic 生成。
+//
+// 返回值:
+//   - *RedisTransport: 节点路由实例。
+func NewRedisTransport(c redis.RedisConf, channel string) *RedisTransport {
+	return &RedisTransport{
+		client: goredis.NewUniversalClient(&goredis.UniversalOptions{
+			Addrs:    strings.Split(c.Host, ","),
+			Password: c.Pass,
+		}),
+		channel: channel,
+	}
+}
+
+func (t *RedisTransport) Publish(ctx context.Context, node string, push *ws.Push) error {
+	body, err := json.Marshal(push)
+	if err != nil {
+		return err
+	}
+	return t.client.Publish(ctx, NodeTopic(t.channel, node), body).Err()
+}
+
+// Subscribe 订阅节点的频道，收到消息后交给 handler 处理，直到 ctx 结束或订阅被关闭。
+//
+// 参数:
+//   - ctx: 上下文对象，取消后停止订阅。
+//   - node: 节点ID。
+//   - handler: 收到推送消息后的处理函数。
+func (t *RedisTransport) Subscribe(ctx context.Context, node string, handler Handler) {
+	sub := t.client.Subscribe(ctx, NodeTopic(t.channel, node))
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var push ws.Push
+			if err := json.Unmarshal([]byte(msg.Payload), &push); err != nil {
+				logx.Errorf("signal unmarshal err: %v, channel: %v", err, msg.Channel)
+				continue
+			}
+			if err := handler(ctx, &push); err != nil {
+				logx.Errorf("signal handle err: %v, channel: %v", err, msg.Channel)
+			}
+		}
+	}
+}
+
+// Close 关闭 Redis 连接。
+func (t *RedisTransport) Close() error {
+	return t.client.Close()
+}
@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/ws"
+	"encoding/json"
+	"fmt"
+	"github.com/zeromicro/go-queue/kq"
+	"sync"
+)
+
+// Handler 网关节点收到路由过来的推送消息后的处理函数。
+type Handler func(ctx context.Context, push *ws.Push) error
+
+// Transport 节点间的消息路由层，负责把推送消息投递到指定的网关节点。
+type Transport interface {
+	// Publish 将推送消息投递到指定节点。
+	Publish(ctx context.Context, node string, push *ws.Push) error
+}
+
+// NodeTopic 返回节点专属的 kafka 主题名称。
+//
+// 每个 im-ws 节点消费自己的主题，task 服务根据连接注册表把消息推送到接收者所在节点的主题中。
+//
+// 参数:
+//   - topic: 推送主题前缀，例如 "msgPushTransfer"。
+//   - node: 节点ID。
+//
+// 返回值:
+//   - string: 节点专属的主题名称。
+func NodeTopic(topic, node string) string {
+	return fmt.Sprintf("%s.%s", topic, node)
+}
+
+// kafkaTransport 基于 kafka 的节点路由，每个节点对应一个独立的主题。
+type kafkaTransport struct {
+	addrs []string
+	topic string
+
+	mu      sync.Mutex
+	pushers map[string]*kq.Pusher
+}
+
+// NewKafkaTransport 创建一个基于 kafka 的节点路由。
+//
+// 参数:
+//   - addrs: kafka 地址列表。
+//   - topic: 推送主题前缀，节点主题由 NodeTopic 生成。
+//
+// 返回值:
+//   - Transport: 节点路由实例。
+func NewKafkaTransport(addrs []string, topic string) Transport {
+	return &kafkaTransport{
+		addrs:   addrs,
+		topic:   topic,
+		pushers: make(map[string]*kq.Pusher),
+	}
+}
+
+func (t *kafkaTransport) Publish(ctx context.Context, node string, push *ws.Push) error {
+	body, err := json.Marshal(push)
+	if err != nil {
+		return err
+	}
+	return t.pusher(node).Push(string(body))
+}
+
+// pusher 获取节点主题对应的推送者，不存在时创建。
+func (t *kafkaTransport) pusher(node string) *kq.Pusher {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.pushers[node]
+	if !ok {
+		p = kq.NewPusher(t.addrs, NodeTopic(t.topic, node))
+		t.pushers[node] = p
+	}
+	return p
+}
+
+// consumer 消费节点主题中的推送消息。
+type consumer struct {
+	handler Handler
+}
+
+// NewConsumer 创建节点主题的消费者，由 im-ws 节点使用 kq 队列加载。
+//
+// 参数:
+//   - handler: 收到推送消息后的处理函数。
+//
+// 返回值:
+//   - kq.ConsumeHandler: kafka 消费处理器。
+func NewConsumer(handler Handler) kq.ConsumeHandler {
+	return &consumer{
+		handler: handler,
+	}
+}
+
+func (c *consumer) Consume(key, value string) error {
+	var push ws.Push
+	if err := json.Unmarshal([]byte(value), &push); err != nil {
+		return err
+	}
+	return c.handler(context.Background(), &push)
+}
+
+// MemoryTransport 基于内存的节点路由，用于在单进程中模拟多个网关节点（例如测试）。
+type MemoryTransport struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewMemoryTransport 创建一个基于内存的节点路由。
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Subscribe 注册节点的消息处理函数。
+func (t *MemoryTransport) Subscribe(node string, handler Handler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handlers[node] = handler
+}
+
+func (t *MemoryTransport) Publish(ctx context.Context, node string, push *ws.Push) error {
+	t.mu.RLock()
+	handler, ok := t.handlers[node]
+	t.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("gateway node %s not subscribed", node)
+	}
+	return handler(ctx, push)
+}
@@ -1,30 +1,35 @@
 package main
 
 import (
+	"compress/flate"
 	"easy-chat/apps/im/ws/internal/config"
 	"easy-chat/apps/im/ws/internal/handler"
 	"easy-chat/apps/im/ws/internal/svc"
 	"easy-chat/apps/im/ws/websocket"
 	"easy-chat/apps/im/ws/websocket/auth"
+	"easy-chat/apps/im/ws/ws"
 	"easy-chat/pkg/configserver"
 	"flag"
 	"fmt"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/proc"
 	"log"
-	"sync"
 	"time"
 )
 
 var configFile = flag.String("f", "/im/conf/im-ws.yaml", "the config file")
-var wg sync.WaitGroup
-
-var signalChan = make(chan struct{})
 
 //var configFile = flag.String("f", "C:/Users/jmh00/GolandProjects/easy-chat/apps/im/ws/etc/dev/im.yaml", "the config file")
 
+// shutdownTimeout 服务优雅关闭的最长时间，进程退出前会等待关闭完成
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	flag.Parse()
 
+	// 配置变更时，通知主循环使用新配置重启服务
+	reload := make(chan config.Config, 1)
+
 	var c config.Config
 	err := configserver.NewConfigServer(*configFile, configserver.NewSail(&configserver.Config{
 		ETCDEndpoints:  "192.168.199.138:3379",
@@ -42,63 +47,100 @@ func main() {
 		}
 		log.Println("load config success, config info:", c)
 
-		// 停止接受请求
-		proc.WrapUp()
-		proc.Shutdown()
-
-		// 等待任务完成
-		<-signalChan
-
-		// 另外启动一个服务
-		go func(c config.Config) {
-			defer func() {
-				wg.Add(1)
-				wg.Done()
-				// 发送信号通知任务完成
-				signalChan <- struct{}{}
-			}()
-			Run(c)
-		}(c)
+		// 只保留最新的一次配置变更
+		select {
+		case <-reload:
+		default:
+		}
+		reload <- c
 		return nil
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	wg.Add(1)
-	go func(c config.Config) {
-		defer func() {
-			wg.Add(1)
-			wg.Done()
-			// 发送信号通知任务完成
-			signalChan <- struct{}{}
-		}()
-
-		Run(c)
-	}(c)
-
-	wg.Wait()
-
+	// 收到退出信号后，留出优雅关闭的时间再强制退出
+	proc.SetTimeToForceQuit(shutdownTimeout + 2*time.Second)
+
+	for {
+		stop := Run(c)
+		select {
+		case c = <-reload:
+			// 旧服务通知客户端重连并排空连接，释放端口后使用新配置启动
+			stop()
+		case <-proc.Done():
+			stop()
+			return
+		}
+	}
 }
 
-func Run(c config.Config) {
+// Run 使用配置启动 im-ws 服务，服务在后台运行。
+//
+// 参数:
+//   - c: 服务配置。
+//
+// 返回:
+//   - func(): 优雅关闭服务的函数，返回时服务已停止、端口已释放。
+func Run(c config.Config) func() {
 	if err := c.SetUp(); err != nil {
 		panic(err)
 	}
 
 	// 服务上下文
 	ctx := svc.NewServiceContext(c)
-	srv := websocket.NewServer(c.ListenOn,
+	opts := []websocket.ServerOptions{
 		websocket.WithWebsocketAuthentication(auth.NewJwtAuth(ctx)),
 		websocket.WithServerAck(websocket.OnlyAck),
-		websocket.WithWebsocketMaxConnectionIdle(7*time.Hour),
+		// 协商 permessage-deflate，压缩大群推送、历史消息等较大的消息
+		websocket.WithServerCompression(flate.BestSpeed, 1024),
+		websocket.WithWebsocketMaxConnectionIdle(7 * time.Hour),
 		websocket.WithServerSendErrCount(3),
-	)
-	defer srv.Stop()
+		websocket.WithServerShutdown(shutdownTimeout, shutdownTimeout/2, time.Second),
+		// 客户端可以通过 Sec-WebSocket-Protocol 协商使用 protobuf 二进制帧
+		websocket.WithServerCodecs(websocket.NewProtoCodec(ws.ProtoPayloads{})),
+	}
+	// 接入跨节点路由，登记用户连接所在的节点
+	opts = append(opts, handler.NodeOptions(ctx)...)
+	// 推送消息需客户端确认送达，未送达的消息在设备重连后补发
+	opts = append(opts, handler.DeliveryOptions(ctx)...)
+	srv := websocket.NewServer(c.ListenOn, opts...)
 
 	// 注册路由
 	handler.RegisterHandlers(srv, ctx)
 
-	fmt.Println("start websocket server at ", c.ListenOn, " ..... ")
-	srv.Start()
+	// 消费路由到当前节点的推送消息
+	q := handler.NodeQueue(srv, ctx)
+	if q != nil {
+		go q.Start()
+	}
+
+	// 订阅路由到当前节点的临时信令
+	stopSignals := handler.NodeSignals(srv, ctx)
+
+	// 刷新本节点连接的在线心跳，清理心跳过期的设备
+	stopPresence := handler.NodePresence(srv, ctx)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		fmt.Println("start websocket server at ", c.ListenOn, " node ", ctx.NodeId, " ..... ")
+		srv.Start()
+	}()
+
+	return func() {
+		// 先停止接收新的推送，未消费的推送留在节点主题中，由重启后的服务继续消费
+		if q != nil {
+			q.Stop()
+		}
+		stopSignals()
+		stopPresence()
+
+		// 通知客户端重连，等待推送确认与处理中的消息完成后关闭连接
+		srv.Stop()
+		<-stopped
+		if err := ctx.SignalTransport.Close(); err != nil {
+			logx.Errorf("close signal transport err: %v", err)
+		}
+	}
 }
@@ -1,6 +1,11 @@
 package config
 
-import "github.com/zeromicro/go-zero/core/service"
+import (
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
+)
 
 // Config 结构体定义了服务的配置选项。
 //
@@ -10,6 +15,10 @@ type Config struct {
 
 	ListenOn string // 服务监听的地址，例如 "0.0.0.0:8080"
 
+	NodeId string `json:",optional"` // 网关节点ID，多节点部署时需唯一，默认使用 主机名-端口
+
+	Redisx redis.RedisConf // Redis 配置，用于登记用户连接所在的网关节点
+
 	JwtAuth struct {
 		AccessSecret string // JWT 认证的访问密钥，用于签名和验证 JWT 令牌
 	}
@@ -28,4 +37,12 @@ type Config struct {
 		Topic string   // 消息已读传输的主题名称
 		Addrs []string // 消息传输服务的地址列表
 	}
+
+	ImRpc zrpc.RpcClientConf // im rpc 服务配置，用于撤回、编辑消息等需要校验的操作
+
+	SocialRpc zrpc.RpcClientConf // social rpc 服务配置，用于校验输入状态等信令的群成员
+
+	// 节点推送消息的消费配置，Topic 为主题前缀，节点实际消费的主题为 Topic.NodeId；
+	// 未配置时只接收 task 服务通过 WebSocket 客户端发送的推送（单节点部署）
+	MsgPushTransfer kq.KqConf `json:",optional"`
 }
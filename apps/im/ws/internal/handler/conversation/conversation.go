@@ -1,20 +1,27 @@
 package conversation
 
 import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/imclient"
 	"easy-chat/apps/im/ws/internal/svc"
 	"easy-chat/apps/im/ws/websocket"
 	"easy-chat/apps/im/ws/ws"
 	"easy-chat/apps/task/mq/mq"
 	"easy-chat/pkg/constants"
+	"easy-chat/pkg/storage"
 	"easy-chat/pkg/wuid"
+	"errors"
 	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
 
 // Chat 处理 WebSocket 消息，进行聊天消息的转发。
 //
 // 该函数返回一个 websocket.HandlerFunc 处理函数，用于接收并处理聊天消息。
-// 它将 WebSocket 消息解码为 ws.Chat 结构体，若消息未指定会话ID，则根据聊天类型生成会话ID。
+// 它将 WebSocket 消息解码为 ws.Chat 结构体，为引用附件的消息补充附件信息，并校验消息内容与消息类型是否相符，
+// 若消息未指定会话ID，则根据聊天类型生成会话ID，引用回复的消息需属于该会话。
 // 处理完成后，将聊天消息推送到消息聊天传输客户端进行处理。
 // 如果解码或消息处理失败，将通过 WebSocket 向客户端发送错误信息。
 //
@@ -36,6 +43,22 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 			return
 		}
 
+		// 引用附件的消息由服务端补充附件信息，再校验消息内容，不合法的消息不进入消息队列
+		if err := fillAttachment(svc, conn.Uid, &data.Msg); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+		if err := data.Msg.Validate(); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
 		// 如果消息未指定会话ID，根据聊天类型生成会话ID
 		if data.ConversationId == "" {
 			switch data.ChatType {
@@ -48,6 +71,15 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 			}
 		}
 
+		// 引用回复的消息必须属于同一会话
+		if err := checkReplyTo(svc, &data); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
 		// 将聊天消息推送 kafka 消息队列进行处理
 		err := svc.MsgChatTransferClient.Push(&mq.MsgChatTransfer{
 			ConversationId: data.ConversationId,
@@ -57,7 +89,12 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 			SendTime:       time.Now().UnixMilli(),
 			MType:          data.Msg.MType,
 			Content:        data.Msg.Content,
+			Payload:        data.Msg.Payload,
+			ReplyTo:        data.Msg.ReplyTo,
+			Mentions:       data.Msg.Mentions,
+			MentionAll:     data.Msg.MentionAll,
 			MsgId:          msg.Id,
+			ChatLogId:      primitive.NewObjectID().Hex(),
 		})
 		if err != nil {
 			// 如果消息推送失败，发送错误信息到客户端
@@ -102,6 +139,7 @@ func MarkRead(svc *svc.ServiceContext) websocket.HandlerFunc {
 			SendId:         conn.Uid,
 			RecvId:         data.RecvId,
 			MsgIds:         data.MsgIds,
+			Seq:            data.Seq,
 		})
 		if err != nil {
 			// 如果消息处理失败，发送错误信息到客户端
@@ -113,3 +151,202 @@ func MarkRead(svc *svc.ServiceContext) websocket.HandlerFunc {
 		}
 	}
 }
+
+// Recall 处理 WebSocket 消息，撤回已发送的消息。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，用于接收并处理撤回消息的请求。
+// 它将 WebSocket 消息解码为 ws.Recall 结构体，并调用 im rpc 服务进行撤回，
+// 撤回权限的校验、消息状态的更新与撤回通知的推送均由 im rpc 服务完成。
+// 如果解码或撤回失败，将通过 WebSocket 向客户端发送错误信息。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext，用于访问 im rpc 服务。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Recall(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Recall
+		// 解码 WebSocket 消息数据为 ws.Recall 结构体
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
+		// 撤回消息
+		_, err := svc.Im.RecallMsg(context.Background(), &imclient.RecallMsgReq{
+			MsgId:  data.MsgId,
+			UserId: conn.Uid,
+		})
+		if err != nil {
+			// 如果撤回失败，发送错误信息到客户端
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// Edit 处理 WebSocket 消息，编辑已发送的文本消息。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，用于接收并处理编辑消息的请求。
+// 它将 WebSocket 消息解码为 ws.Edit 结构体，并调用 im rpc 服务进行编辑，
+// 编辑权限的校验、历史版本的保存与编辑通知的推送均由 im rpc 服务完成。
+// 如果解码或编辑失败，将通过 WebSocket 向客户端发送错误信息。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext，用于访问 im rpc 服务。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Edit(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Edit
+		// 解码 WebSocket 消息数据为 ws.Edit 结构体
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
+		// 编辑消息
+		_, err := svc.Im.EditMsg(context.Background(), &imclient.EditMsgReq{
+			MsgId:      data.MsgId,
+			UserId:     conn.Uid,
+			MsgContent: data.Content,
+		})
+		if err != nil {
+			// 如果编辑失败，发送错误信息到客户端
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// React 处理 WebSocket 消息，添加或移除表情回应。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，用于接收并处理表情回应的请求。
+// 它将 WebSocket 消息解码为 ws.React 结构体，并调用 im rpc 服务更新回应，
+// 回应权限的校验与回应变更通知的推送均由 im rpc 服务完成。
+// 如果解码或操作失败，将通过 WebSocket 向客户端发送错误信息。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext，用于访问 im rpc 服务。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func React(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.React
+		// 解码 WebSocket 消息数据为 ws.React 结构体
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
+		// 更新表情回应
+		_, err := svc.Im.ReactMsg(context.Background(), &imclient.ReactMsgReq{
+			MsgId:  data.MsgId,
+			UserId: conn.Uid,
+			Emoji:  data.Emoji,
+			Remove: data.Remove,
+		})
+		if err != nil {
+			// 如果操作失败，发送错误信息到客户端
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// fillAttachment 为引用附件的消息补充附件的元信息。
+//
+// 附件上传完成时服务端已识别文件类型、记录图片尺寸并生成缩略图，
+// 图片消息据此自动携带尺寸与缩略图，客户端无需自行计算；客户端已填写的字段保持不变。
+// 发送者只能引用自己上传的附件，引用他人的附件与引用不存在的附件一样被拒绝。
+//
+// 参数:
+//   - svc: 服务上下文，用于读取附件的元信息。
+//   - uid: 发送者的用户ID。
+//   - msg: 要补充的消息。
+//
+// 返回:
+//   - error: 引用的附件不存在、不属于发送者或读取失败时返回错误。
+func fillAttachment(svc *svc.ServiceContext, uid string, msg *ws.Msg) error {
+	p := msg.Payload
+	if p == nil || p.FileId == "" {
+		return nil
+	}
+	ctx := context.Background()
+	owned, err := storage.Owned(ctx, svc.Redis, uid, p.FileId)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return storage.ErrFileNotFound
+	}
+	meta, err := storage.GetMeta(ctx, svc.Redis, p.FileId)
+	if err != nil {
+		return err
+	}
+
+	if p.Name == "" {
+		p.Name = meta.Name
+	}
+	if p.Size == 0 {
+		p.Size = meta.Size
+	}
+	if p.Mime == "" {
+		p.Mime = meta.Mime
+	}
+	if msg.MType == constants.ImageMType && meta.Thumb != "" {
+		p.Width = int32(meta.Width)
+		p.Height = int32(meta.Height)
+		p.Thumb = meta.Thumb
+	}
+	return nil
+}
+
+// ErrReplyToInvalid 引用回复的消息不存在或不属于当前会话。
+var ErrReplyToInvalid = errors.New("引用的消息不存在或不属于当前会话")
+
+// checkReplyTo 校验引用回复的消息存在且属于同一会话。
+//
+// 参数:
+//   - svc: 服务上下文，用于查询聊天记录。
+//   - data: 聊天消息，会话ID已确定。
+//
+// 返回:
+//   - error: 引用的消息不合法时返回 ErrReplyToInvalid。
+func checkReplyTo(svc *svc.ServiceContext, data *ws.Chat) error {
+	if data.ReplyTo == "" {
+		return nil
+	}
+	replyTo, err := svc.ChatLogModel.FindOne(context.Background(), data.ReplyTo)
+	if err != nil {
+		if errors.Is(err, immodels.ErrNotFound) || errors.Is(err, immodels.ErrInvalidObjectId) {
+			return ErrReplyToInvalid
+		}
+		return err
+	}
+	if replyTo.ConversationId != data.ConversationId {
+		return ErrReplyToInvalid
+	}
+	return nil
+}
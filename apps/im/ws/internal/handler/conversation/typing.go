@@ -0,0 +1,268 @@
+package conversation
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/internal/handler/push"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/wuid"
+	"errors"
+	"github.com/mitchellh/mapstructure"
+	"github.com/zeromicro/go-zero/core/collection"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// typingThrottle 同一发送者在同一会话中转发"正在输入"信令的最小间隔
+	typingThrottle = 3 * time.Second
+	// typingTimeout 超过该时间未收到新的输入信令，服务端自动通知对方停止输入
+	typingTimeout = 6 * time.Second
+	// groupMembersExpire 群成员缓存的有效期，成员变更最多延迟该时间生效
+	groupMembersExpire = time.Minute
+)
+
+var (
+	// ErrTypingRecvId 输入状态信令缺少接收者，或接收者为自己。
+	ErrTypingRecvId = errors.New("输入状态的接收者无效")
+	// ErrTypingNotMember 发送者不是群成员。
+	ErrTypingNotMember = errors.New("不是群成员，不能发送输入状态")
+)
+
+// Typing 处理 WebSocket 消息，转发输入状态信令。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，用于接收客户端的输入状态并转发给会话中的其他参与者。
+// 输入状态是临时信令，不经过 kafka 与 MongoDB：本节点上的接收者直接发送，其他节点经 Redis 发布订阅转发，
+// 接收者离线或节点不可达时直接丢弃，也不需要客户端确认送达。
+// 同一发送者在同一会话中的"正在输入"信令按 typingThrottle 节流，
+// 超过 typingTimeout 未收到新的输入信令时，服务端自动转发停止输入。
+// 群聊只允许群成员发送，群成员列表在本节点缓存 groupMembersExpire，避免每次输入都查询 social rpc。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext，用于访问 social rpc 服务与信令路由。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Typing(svc *svc.ServiceContext) websocket.HandlerFunc {
+	members, err := collection.NewCache(groupMembersExpire, collection.WithName("typing-group-members"))
+	if err != nil {
+		panic(err)
+	}
+	tracker := newTypingTracker(typingThrottle, typingTimeout)
+
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Typing
+		// 解码 WebSocket 消息数据为 ws.Typing 结构体
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
+		// 确定会话与接收者
+		recvIds, err := typingRecipients(svc, members, conn.Uid, &data)
+		if err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
+		relay := func(typing bool) {
+			relayTyping(srv, svc, &ws.Push{
+				ConversationId: data.ConversationId,
+				ChatType:       data.ChatType,
+				SendId:         conn.Uid,
+				RecvId:         data.RecvId,
+				RecvIds:        recvIds,
+				SendTime:       time.Now().UnixMilli(),
+				ContentType:    constants.ContentTyping,
+				Typing:         typing,
+			})
+		}
+
+		key := conn.Uid + ":" + data.ConversationId
+		if !data.Typing {
+			if tracker.Stop(key) {
+				relay(false)
+			}
+			return
+		}
+		if tracker.Start(key, func() { relay(false) }) {
+			relay(true)
+		}
+	}
+}
+
+// typingRecipients 补全输入状态的会话ID，并返回需要通知的接收者。
+//
+// 单聊的接收者为对方；群聊的接收者为除发送者以外的群成员，发送者必须是群成员。
+//
+// 参数:
+//   - svc: 服务上下文，用于查询群成员。
+//   - members: 群成员缓存，键为群ID。
+//   - uid: 发送者ID。
+//   - data: 输入状态信令，会补全其中的会话ID。
+//
+// 返回:
+//   - []string: 群聊的接收者ID列表，单聊为 nil。
+//   - error: 接收者无效、发送者不是群成员或查询失败时返回错误。
+func typingRecipients(svc *svc.ServiceContext, members *collection.Cache, uid string, data *ws.Typing) ([]string, error) {
+	if data.RecvId == "" || data.RecvId == uid {
+		return nil, ErrTypingRecvId
+	}
+
+	switch data.ChatType {
+	case constants.SingleChatType:
+		data.ConversationId = wuid.CombineId(uid, data.RecvId)
+		return nil, nil
+	case constants.GroupChatType:
+		data.ConversationId = data.RecvId
+	default:
+		return nil, ErrTypingRecvId
+	}
+
+	val, err := members.Take(data.RecvId, func() (any, error) {
+		resp, err := svc.Social.GroupUsers(context.Background(), &socialclient.GroupUsersReq{
+			GroupId: data.RecvId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		uids := make([]string, 0, len(resp.List))
+		for _, member := range resp.List {
+			uids = append(uids, member.UserId)
+		}
+		return uids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uids := val.([]string)
+	if !slices.Contains(uids, uid) {
+		return nil, ErrTypingNotMember
+	}
+	recvIds := make([]string, 0, len(uids))
+	for _, id := range uids {
+		if id != uid {
+			recvIds = append(recvIds, id)
+		}
+	}
+	return recvIds, nil
+}
+
+// relayTyping 转发输入状态信令，本节点上的接收者直接发送，其他节点经信令路由转发。
+func relayTyping(srv *websocket.Server, svc *svc.ServiceContext, data *ws.Push) {
+	push.Deliver(srv, data)
+	if err := svc.Signals.DeliverRemote(context.Background(), data, svc.NodeId); err != nil {
+		srv.Errorf("typing relay err: %v, conversation: %v", err, data.ConversationId)
+	}
+}
+
+// typingSession 发送者在某个会话中的输入状态。
+type typingSession struct {
+	lastStart time.Time   // 最近一次转发"正在输入"的时间
+	deadline  time.Time   // 输入状态的超时时间，每次收到输入信令时延长
+	timer     *time.Timer // 超时检查定时器
+	expire    func()      // 超时后转发停止输入
+}
+
+// typingTracker 记录各发送者在各会话中的输入状态，负责节流与超时自动停止。
+type typingTracker struct {
+	throttle time.Duration
+	timeout  time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*typingSession
+}
+
+// newTypingTracker 创建输入状态记录。
+//
+// 参数:
+//   - throttle: 转发"正在输入"信令的最小间隔。
+//   - timeout: 未收到新的输入信令时，自动停止输入的超时时间。
+func newTypingTracker(throttle, timeout time.Duration) *typingTracker {
+	return &typingTracker{
+		throttle: throttle,
+		timeout:  timeout,
+		sessions: make(map[string]*typingSession),
+	}
+}
+
+// Start 记录正在输入，并延长输入状态的超时时间。
+//
+// 参数:
+//   - key: 发送者与会话组成的键。
+//   - expire: 超时后转发停止输入的函数，以最近一次传入的为准。
+//
+// 返回:
+//   - bool: 是否需要转发"正在输入"信令，距上次转发不足节流间隔时返回 false。
+func (t *typingTracker) Start(key string, expire func()) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	s, ok := t.sessions[key]
+	if !ok {
+		s = &typingSession{}
+		s.timer = time.AfterFunc(t.timeout, func() {
+			t.expire(key, s)
+		})
+		t.sessions[key] = s
+	}
+	s.deadline = now.Add(t.timeout)
+	s.expire = expire
+
+	if ok && now.Sub(s.lastStart) < t.throttle {
+		return false
+	}
+	s.lastStart = now
+	return true
+}
+
+// Stop 结束输入状态。
+//
+// 参数:
+//   - key: 发送者与会话组成的键。
+//
+// 返回:
+//   - bool: 是否需要转发停止输入信令，输入状态不存在（未开始或已超时）时返回 false。
+func (t *typingTracker) Stop(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.sessions[key]
+	if !ok {
+		return false
+	}
+	s.timer.Stop()
+	delete(t.sessions, key)
+	return true
+}
+
+// expire 定时器到期时检查输入状态，期间收到过新的输入信令则继续等待，否则结束输入状态并转发停止输入。
+func (t *typingTracker) expire(key string, s *typingSession) {
+	t.mu.Lock()
+	if t.sessions[key] != s {
+		t.mu.Unlock()
+		return
+	}
+	if remain := time.Until(s.deadline); remain > 0 {
+		s.timer.Reset(remain)
+		t.mu.Unlock()
+		return
+	}
+	delete(t.sessions, key)
+	expire := s.expire
+	t.mu.Unlock()
+
+	expire()
+}
@@ -0,0 +1,46 @@
+package conversation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTypingTracker(t *testing.T) {
+	tracker := newTypingTracker(50*time.Millisecond, 100*time.Millisecond)
+	expired := make(chan struct{}, 1)
+	expire := func() { expired <- struct{}{} }
+
+	if !tracker.Start("u1:c1", expire) {
+		t.Fatal("第一次输入应转发")
+	}
+	if tracker.Start("u1:c1", expire) {
+		t.Fatal("节流间隔内的输入不应转发")
+	}
+	if !tracker.Start("u2:c1", expire) {
+		t.Fatal("不同发送者分别节流")
+	}
+	if !tracker.Stop("u2:c1") || tracker.Stop("u2:c1") {
+		t.Fatal("停止输入只应转发一次")
+	}
+
+	// 持续输入会延长超时时间
+	time.Sleep(70 * time.Millisecond)
+	if !tracker.Start("u1:c1", expire) {
+		t.Fatal("超过节流间隔的输入应转发")
+	}
+	time.Sleep(70 * time.Millisecond)
+	select {
+	case <-expired:
+		t.Fatal("持续输入时不应超时")
+	default:
+	}
+
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		t.Fatal("停止输入后应超时")
+	}
+	if tracker.Stop("u1:c1") {
+		t.Fatal("超时后的停止输入不应再转发")
+	}
+}
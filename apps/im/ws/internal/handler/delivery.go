@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+const (
+	// undeliveredExpire 未送达消息的保存时间（秒）
+	undeliveredExpire = 7 * 24 * 60 * 60
+	// undeliveredLimit 每个设备最多保存的未送达消息数量，超出时丢弃最早的消息，由客户端按序号离线同步
+	undeliveredLimit = 500
+)
+
+// takeUndeliveredScript 原子地取出并删除设备的全部未送达消息
+var takeUndeliveredScript = redis.NewScript(`
+local msgs = redis.call("LRANGE", KEYS[1], 0, -1)
+redis.call("DEL", KEYS[1])
+return msgs
+`)
+
+// DeliveryOptions 返回推送消息送达确认所需的服务器选项。
+//
+// 推送给设备的消息需要客户端确认送达，多次重传仍未确认或连接提前断开的消息，
+// 会以 用户ID:设备ID 为键保存到 Redis 中，设备重新连接后补发。
+//
+// 参数:
+//   - svc: 服务上下文，提供 Redis 客户端。
+//
+// 返回:
+//   - []websocket.ServerOptions: 推送送达确认相关的服务器选项。
+func DeliveryOptions(svc *svc.ServiceContext) []websocket.ServerOptions {
+	return []websocket.ServerOptions{
+		websocket.WithServerPushAck(func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+			if err := saveUndelivered(svc, conn, msg); err != nil {
+				logx.Errorf("save undelivered err: %v, uid: %v, device: %v, mid: %v", err, conn.Uid, conn.DeviceId, msg.Id)
+			}
+		}),
+		websocket.WithServerConnectHook(func(srv *websocket.Server, conn *websocket.Conn) {
+			if err := replayUndelivered(svc, srv, conn); err != nil {
+				logx.Errorf("replay undelivered err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+			}
+		}),
+	}
+}
+
+// saveUndelivered 保存设备未送达的推送消息。
+func saveUndelivered(svc *svc.ServiceContext, conn *websocket.Conn, msg *websocket.Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	key := undeliveredKey(conn)
+	if _, err := svc.Redis.RpushCtx(ctx, key, string(body)); err != nil {
+		return err
+	}
+	if err := svc.Redis.LtrimCtx(ctx, key, -undeliveredLimit, -1); err != nil {
+		return err
+	}
+	return svc.Redis.ExpireCtx(ctx, key, undeliveredExpire)
+}
+
+// replayUndelivered 设备重新连接后补发之前未送达的推送消息。
+func replayUndelivered(svc *svc.ServiceContext, srv *websocket.Server, conn *websocket.Conn) error {
+	res, err := svc.Redis.ScriptRunCtx(context.Background(), takeUndeliveredScript, []string{undeliveredKey(conn)})
+	if err != nil {
+		return err
+	}
+
+	// 未送达消息已从 Redis 中取出，单条消息补发失败时继续补发其余消息，
+	// 补发的消息仍需确认送达，失败的消息会在重传失败或连接断开时重新保存
+	var lastErr error
+	list, _ := res.([]interface{})
+	for _, v := range list {
+		body, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		var msg websocket.Message
+		if err := json.Unmarshal([]byte(body), &msg); err != nil {
+			logx.Errorf("unmarshal undelivered err: %v, body: %v", err, body)
+			continue
+		}
+		if err := srv.Push(&msg, conn); err != nil {
+			logx.Errorf("replay undelivered push err: %v, uid: %v, device: %v, mid: %v", err, conn.Uid, conn.DeviceId, msg.Id)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// undeliveredKey 设备未送达消息的 Redis 键
+func undeliveredKey(conn *websocket.Conn) string {
+	return constants.RedisWsUndelivered + conn.Uid + ":" + conn.DeviceId
+}
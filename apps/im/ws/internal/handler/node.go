@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/gateway"
+	"easy-chat/apps/im/ws/internal/handler/push"
+	"easy-chat/apps/im/ws/internal/handler/user"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/queue"
+	"time"
+)
+
+// NodeOptions 返回接入跨节点路由所需的服务器选项。
+//
+// 连接建立时在连接注册表中登记 用户设备 -> 当前节点，连接断开时注销，
+// task 服务据此只向持有接收者连接的节点投递消息。
+// 同时登记/注销设备的在线状态，用户上线或离线时通知订阅了其在线状态的好友。
+//
+// 参数:
+//   - svc: 服务上下文，提供连接注册表与当前节点ID。
+//
+// 返回:
+//   - []websocket.ServerOptions: 连接生命周期回调选项。
+func NodeOptions(svc *svc.ServiceContext) []websocket.ServerOptions {
+	return []websocket.ServerOptions{
+		websocket.WithServerConnectHook(func(srv *websocket.Server, conn *websocket.Conn) {
+			if err := svc.Registry.Register(context.Background(), svc.NodeId, conn.Uid, conn.DeviceId); err != nil {
+				logx.Errorf("register route err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+			}
+			user.Online(srv, svc, conn)
+		}),
+		websocket.WithServerCloseHook(func(srv *websocket.Server, conn *websocket.Conn) {
+			if err := svc.Registry.Unregister(context.Background(), svc.NodeId, conn.Uid, conn.DeviceId); err != nil {
+				logx.Errorf("unregister route err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+			}
+			user.Offline(srv, svc, conn)
+		}),
+	}
+}
+
+// NodeQueue 创建当前节点推送主题的消费队列。
+//
+// 队列消费 task 服务路由到当前节点的推送消息，并投递给本节点上的接收者。
+// 未配置节点推送主题时返回 nil，此时只通过 "push" 路由接收推送。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - svc: 服务上下文。
+//
+// 返回:
+//   - queue.MessageQueue: 节点推送消费队列，未配置时为 nil。
+func NodeQueue(srv *websocket.Server, svc *svc.ServiceContext) queue.MessageQueue {
+	c := svc.Config.MsgPushTransfer
+	if len(c.Brokers) == 0 || c.Topic == "" {
+		return nil
+	}
+
+	c.Topic = gateway.NodeTopic(c.Topic, svc.NodeId)
+	if c.Group == "" {
+		c.Group = c.Topic
+	}
+	return kq.MustNewQueue(c, gateway.NewConsumer(func(ctx context.Context, data *ws.Push) error {
+		push.Deliver(srv, data)
+		return nil
+	}))
+}
+
+// NodeSignals 订阅路由到当前节点的临时信令，例如其他节点转发过来的输入状态与在线状态变更。
+//
+// 信令经 Redis 发布订阅传递，投递给本节点上的接收者时不需要客户端确认送达，
+// 在线状态变更只发送给订阅了该用户在线状态的连接。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - svc: 服务上下文。
+//
+// 返回:
+//   - func(): 停止订阅的函数。
+func NodeSignals(srv *websocket.Server, svc *svc.ServiceContext) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go svc.SignalTransport.Subscribe(ctx, svc.NodeId, func(ctx context.Context, data *ws.Push) error {
+		if data.ContentType == constants.ContentPresence {
+			user.DeliverPresence(srv, svc, data)
+			return nil
+		}
+		push.Deliver(srv, data)
+		return nil
+	})
+	return cancel
+}
+
+// presenceSweepLimit 每次清理心跳过期设备的数量上限
+const presenceSweepLimit = 100
+
+// NodePresence 周期性刷新本节点上所有连接的在线心跳与路由，并清理心跳过期的设备。
+//
+// 心跳按在线状态有效期的三分之一刷新，同时在连接注册表中重新登记连接所在的节点，
+// 路由与在线状态使用相同的有效期，长时间保持的连接不会因路由过期而收不到跨节点推送；
+// 连接因空闲超时等原因断开后不再刷新，节点异常退出时其设备的心跳与路由随之过期，
+// 由仍在运行的节点清理心跳过期的设备并通知好友离线。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - svc: 服务上下文。
+//
+// 返回:
+//   - func(): 停止刷新的函数。
+func NodePresence(srv *websocket.Server, svc *svc.ServiceContext) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(svc.Presence.TTL() / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			// 刷新本节点上所有连接的路由与心跳
+			for _, conn := range srv.GetConns(srv.GetUsers()...) {
+				if err := svc.Registry.Register(ctx, svc.NodeId, conn.Uid, conn.DeviceId); err != nil {
+					logx.Errorf("refresh route err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+				}
+				user.Online(srv, svc, conn)
+			}
+
+			// 清理心跳过期的设备
+			for {
+				uids, lastSeen, err := svc.Presence.Sweep(ctx, presenceSweepLimit)
+				if err != nil {
+					logx.Errorf("presence sweep err: %v", err)
+					break
+				}
+				for _, uid := range uids {
+					user.Notify(srv, svc, uid, false, lastSeen)
+				}
+				if len(uids) < presenceSweepLimit {
+					break
+				}
+			}
+		}
+	}()
+	return cancel
+}
@@ -6,6 +6,7 @@ import (
 	"easy-chat/apps/im/ws/ws"
 	"easy-chat/pkg/constants"
 	"github.com/mitchellh/mapstructure"
+	"slices"
 )
 
 // Push 处理 WebSocket 消息，转发推送消息，由 kafka 消息队列远程调用。
@@ -25,33 +26,48 @@ func Push(svc *svc.ServiceContext) websocket.HandlerFunc {
 		var data ws.Push
 		if err := mapstructure.Decode(msg.Data, &data); err != nil {
 			// 如果解码失败，发送错误信息到客户端
-			err := srv.Send(websocket.NewErrMessage(err))
+			err := srv.Send(websocket.NewErrMessage(err), conn)
 			if err != nil {
 				srv.Errorf("push err: %v", err)
 			}
 			return
 		}
 
-		// 根据聊天类型进行不同的推送处理
-		switch data.ChatType {
-		case constants.SingleChatType:
-			// 处理单聊消息推送
-			err := single(srv, &data, data.RecvId)
-			if err != nil {
-				srv.Errorf("push err: %v", err)
-				return
-			}
-		case constants.GroupChatType:
-			// 处理群聊消息推送
-			group(srv, &data)
+		Deliver(srv, &data)
+	}
+}
+
+// Deliver 将推送消息投递给当前节点上的接收者。
+//
+// 该函数根据聊天类型将消息推送到目标用户，既用于 "push" 路由，
+// 也用于消费 task 服务按节点路由过来的推送消息。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - data: 包含推送消息的数据结构体。
+func Deliver(srv *websocket.Server, data *ws.Push) {
+	// 根据聊天类型进行不同的推送处理
+	switch data.ChatType {
+	case constants.SingleChatType:
+		// 处理单聊消息推送
+		err := single(srv, data, data.RecvId)
+		if err != nil {
+			srv.Errorf("push err: %v", err)
+			return
 		}
+	case constants.GroupChatType:
+		// 处理群聊消息推送
+		group(srv, data)
 	}
 }
 
 // single 处理单聊消息的推送。
 //
-// 该函数根据接收者ID从服务器获取连接，并将消息推送给接收者。
-// 如果目标用户离线，当前实现没有处理离线用户的逻辑。
+// 该函数根据接收者ID从服务器获取该用户所有在线设备的连接，并将消息推送到每一个设备，
+// 开启推送确认时，各设备需要确认送达，未送达的消息会被保存以便设备重连后补发。
+// 如果目标用户离线，消息由客户端上线后按序号离线同步。
+// 目标用户对会话开启了免打扰时，推送中携带静默标记，客户端据此不做提醒。
+// 输入状态等临时信令直接发送，不需要确认送达。
 // 如果推送过程中出现错误，记录错误日志。
 //
 // 参数:
@@ -62,25 +78,47 @@ func Push(svc *svc.ServiceContext) websocket.HandlerFunc {
 // 返回:
 //   - error: 发生的错误（如果有的话），返回nil表示推送成功。
 func single(srv *websocket.Server, data *ws.Push, recvId string) error {
-	// 获取发送的目标用户连接
-	rconn := srv.GetConn(recvId)
-	if rconn == nil {
-		// 目标用户离线，当前实现未处理离线用户的逻辑
+	// 获取发送的目标用户所有设备的连接
+	rconns := srv.GetConn(recvId)
+	if len(rconns) == 0 {
+		// 目标用户离线，由客户端上线后按序号同步
 		return nil
 	}
 	// 发送消息
 	srv.Infof("push msg: %v", data)
-	return srv.Send(websocket.NewMessage(data.SendId, &ws.Chat{
+	msg := websocket.NewMessage(data.SendId, &ws.Chat{
 		ConversationId: data.ConversationId,
+		Seq:            data.Seq,
 		ChatType:       data.ChatType,
 		SendTime:       data.SendTime,
+		ContentType:    data.ContentType,
+		Silent:         slices.Contains(data.Muted, recvId),
+		ReadSeq:        data.ReadSeq,
+		Unread:         data.Unread,
+		Typing:         data.Typing,
 		Msg: ws.Msg{
-			MsgId:       data.MsgId,
-			ReadRecords: data.ReadRecords,
-			MType:       data.MType,
-			Content:     data.Content,
+			MsgId:      data.MsgId,
+			ReadCounts: data.ReadCounts,
+			Reactions:  data.Reactions,
+			MType:      data.MType,
+			Content:    data.Content,
+			Payload:    data.Payload,
+			ReplyTo:    data.ReplyTo,
+			Mentions:   data.Mentions,
+			MentionAll: data.MentionAll,
+			EditedAt:   data.EditedAt,
 		},
-	}), rconn)
+	})
+	// 输入状态等临时信令不需要确认送达，也不保存补发
+	if data.ContentType == constants.ContentTyping {
+		return srv.Send(msg, rconns...)
+	}
+	// 聊天消息以消息ID作为推送ID，客户端据此确认送达与去重；
+	// 撤回、编辑、表情回应等通知携带的是原消息ID，推送ID由服务器生成，避免与原消息的推送冲突
+	if data.ContentType == constants.ContentChatMsg {
+		msg.Id = data.MsgId
+	}
+	return srv.Push(msg, rconns...)
 }
 
 // group 处理群聊消息的推送。
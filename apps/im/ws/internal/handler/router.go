@@ -14,6 +14,14 @@ func RegisterHandlers(srv *websocket.Server, svc *svc.ServiceContext) {
 			Method:  "user.online",
 			Handler: user.OnLine(svc),
 		},
+		{
+			Method:  "presence.subscribe",
+			Handler: user.Subscribe(svc),
+		},
+		{
+			Method:  "presence.unsubscribe",
+			Handler: user.Unsubscribe(svc),
+		},
 		{
 			Method:  "conversation.chat",
 			Handler: conversation.Chat(svc),
@@ -22,6 +30,22 @@ func RegisterHandlers(srv *websocket.Server, svc *svc.ServiceContext) {
 			Method:  "conversation.markChat",
 			Handler: conversation.MarkRead(svc),
 		},
+		{
+			Method:  "conversation.recall",
+			Handler: conversation.Recall(svc),
+		},
+		{
+			Method:  "conversation.edit",
+			Handler: conversation.Edit(svc),
+		},
+		{
+			Method:  "conversation.react",
+			Handler: conversation.React(svc),
+		},
+		{
+			Method:  "conversation.typing",
+			Handler: conversation.Typing(svc),
+		},
 		{
 			Method:  "push",
 			Handler: push.Push(svc),
@@ -0,0 +1,222 @@
+package user
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"github.com/mitchellh/mapstructure"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Subscribe 处理 WebSocket 消息，订阅好友的在线状态。
+//
+// 该函数返回一个 websocket.HandlerFunc 处理函数，订阅只对当前连接有效，连接断开后自动取消。
+// 只能订阅好友的在线状态，不是好友的用户ID会被忽略；未指定用户ID时订阅全部好友。
+// 订阅成功后立即返回这些好友当前的在线状态，之后好友上线或离线时推送 ContentPresence 通知。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext，用于访问 social rpc 服务与在线状态。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Subscribe(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.PresenceSubscribe
+		// 解码 WebSocket 消息数据为 ws.PresenceSubscribe 结构体
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+
+		// 只保留好友
+		uids, err := friendIds(svc, conn.Uid)
+		if err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+		if len(data.UserIds) > 0 {
+			uids = slices.DeleteFunc(data.UserIds, func(uid string) bool {
+				return !slices.Contains(uids, uid)
+			})
+		}
+		svc.Subscriptions.Subscribe(subscriber(conn), uids...)
+
+		// 返回当前的在线状态
+		statuses, err := svc.Presence.Statuses(context.Background(), uids...)
+		if err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+		res := &ws.PresenceStatuses{
+			ContentType: constants.ContentPresence,
+			Online:      make(map[string]bool, len(statuses)),
+			LastSeen:    make(map[string]int64, len(statuses)),
+		}
+		for uid, status := range statuses {
+			res.Online[uid] = status.Online
+			res.LastSeen[uid] = status.LastSeen
+		}
+		if err := srv.Send(websocket.NewMessage(conn.Uid, res), conn); err != nil {
+			srv.Errorf("presence statuses send error: %v", err)
+		}
+	}
+}
+
+// Unsubscribe 处理 WebSocket 消息，取消订阅好友的在线状态，未指定用户ID时取消当前连接的全部订阅。
+//
+// 参数:
+//   - svc: 包含服务上下文的 *svc.ServiceContext，用于访问在线状态订阅表。
+//
+// 返回:
+//   - websocket.HandlerFunc: 处理 WebSocket 消息的处理函数。
+func Unsubscribe(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.PresenceSubscribe
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			err := srv.Send(websocket.NewErrMessage(err), conn)
+			if err != nil {
+				srv.Errorf("error message send error: %v", err)
+			}
+			return
+		}
+		svc.Subscriptions.Unsubscribe(subscriber(conn), data.UserIds...)
+	}
+}
+
+// Online 登记连接所在设备在线或刷新设备心跳，用户由离线转为在线时通知好友。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - svc: 服务上下文。
+//   - conn: 设备连接。
+func Online(srv *websocket.Server, svc *svc.ServiceContext, conn *websocket.Conn) {
+	changed, err := svc.Presence.Online(context.Background(), svc.NodeId, conn.Uid, conn.DeviceId)
+	if err != nil {
+		srv.Errorf("presence online err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+		return
+	}
+	if changed {
+		Notify(srv, svc, conn.Uid, true, time.Now().UnixMilli())
+	}
+}
+
+// Offline 注销连接所在的设备并取消连接的订阅，用户由在线转为离线时通知好友。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - svc: 服务上下文。
+//   - conn: 已断开的设备连接。
+func Offline(srv *websocket.Server, svc *svc.ServiceContext, conn *websocket.Conn) {
+	svc.Subscriptions.Unsubscribe(subscriber(conn))
+
+	lastSeen, err := svc.Presence.Offline(context.Background(), svc.NodeId, conn.Uid, conn.DeviceId)
+	if err != nil {
+		srv.Errorf("presence offline err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+		return
+	}
+	if lastSeen > 0 {
+		Notify(srv, svc, conn.Uid, false, lastSeen)
+	}
+}
+
+// Notify 将用户的在线状态变更通知给好友。
+//
+// 通知是临时信令：本节点上的好友直接发送，其他节点经信令路由转发，
+// 各节点只发送给订阅了该用户在线状态的连接。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - svc: 服务上下文。
+//   - uid: 在线状态变更的用户ID。
+//   - online: 是否在线。
+//   - lastSeen: 最后在线的时间戳（毫秒）。
+func Notify(srv *websocket.Server, svc *svc.ServiceContext, uid string, online bool, lastSeen int64) {
+	friends, err := friendIds(svc, uid)
+	if err != nil {
+		srv.Errorf("presence notify err: %v, uid: %v", err, uid)
+		return
+	}
+	if len(friends) == 0 {
+		return
+	}
+
+	data := &ws.Push{
+		SendId:      uid,
+		RecvIds:     friends,
+		SendTime:    time.Now().UnixMilli(),
+		ContentType: constants.ContentPresence,
+		Online:      online,
+		LastSeen:    lastSeen,
+	}
+	DeliverPresence(srv, svc, data)
+	if err := svc.Signals.DeliverRemote(context.Background(), data, svc.NodeId); err != nil {
+		srv.Errorf("presence relay err: %v, uid: %v", err, uid)
+	}
+}
+
+// DeliverPresence 将在线状态变更通知发送给本节点上订阅了该用户的连接。
+//
+// 订阅者必须仍在通知的接收者（即好友）之中，订阅之后解除好友关系的连接不再收到通知。
+//
+// 参数:
+//   - srv: WebSocket 服务器实例。
+//   - svc: 服务上下文。
+//   - data: 在线状态变更通知，SendId 为状态变更的用户。
+func DeliverPresence(srv *websocket.Server, svc *svc.ServiceContext, data *ws.Push) {
+	msg := websocket.NewMessage(data.SendId, &ws.Chat{
+		ChatType:    data.ChatType,
+		SendId:      data.SendId,
+		SendTime:    data.SendTime,
+		ContentType: data.ContentType,
+		Online:      data.Online,
+		LastSeen:    data.LastSeen,
+	})
+
+	for _, sub := range svc.Subscriptions.Subscribers(data.SendId) {
+		uid, deviceId, _ := strings.Cut(sub, "/")
+		if !slices.Contains(data.RecvIds, uid) {
+			continue
+		}
+		conn := srv.GetDeviceConn(uid, deviceId)
+		if conn == nil {
+			continue
+		}
+		if err := srv.Send(msg, conn); err != nil {
+			srv.Errorf("presence send err: %v, uid: %v", err, uid)
+		}
+	}
+}
+
+// subscriber 返回连接在订阅表中的订阅者标识：用户ID/设备ID。
+func subscriber(conn *websocket.Conn) string {
+	return conn.Uid + "/" + conn.DeviceId
+}
+
+// friendIds 查询用户的好友ID列表。
+func friendIds(svc *svc.ServiceContext, uid string) ([]string, error) {
+	resp, err := svc.Social.FriendList(context.Background(), &socialclient.FriendListReq{
+		UserId: uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]string, 0, len(resp.List))
+	for _, friend := range resp.List {
+		uids = append(uids, friend.FriendUid)
+	}
+	return uids, nil
+}
@@ -2,13 +2,36 @@ package svc
 
 import (
 	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/apps/im/ws/gateway"
 	"easy-chat/apps/im/ws/internal/config"
+	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/mqclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/presence"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
 	Config config.Config
 
+	// 当前网关节点ID
+	NodeId string
+
+	*redis.Redis
+	gateway.Registry
+
+	// 临时信令的跨节点路由，经 Redis 发布订阅转发，不经过 kafka 与 MongoDB
+	Signals         *gateway.Router
+	SignalTransport *gateway.RedisTransport
+
+	// 用户在线状态，以及本节点上各连接对好友在线状态的订阅
+	Presence      *presence.Store
+	Subscriptions *presence.Subscriptions
+
+	imclient.Im
+	socialclient.Social
 	immodels.ChatLogModel
 	mqclient.MsgChatTransferClient
 	mqclient.MsgReadTransferClient
@@ -28,9 +51,33 @@ type ServiceContext struct {
 //	- `MsgChatTransferClient`: 初始化消息聊天传输客户端，用于处理聊天消息的传输。
 //	- `MsgReadTransferClient`: 初始化消息已读传输客户端，用于处理消息已读状态的传输。
 //	- `ChatLogModel`: 初始化聊天日志模型，用于与 MongoDB 交互，存储和检索聊天日志。
+//	- `Registry`: 初始化连接注册表，用于登记用户连接所在的网关节点。
+//	- `Im`: 初始化 im rpc 客户端，用于撤回、编辑消息等需要校验的操作。
+//	- `Social`: 初始化 social rpc 客户端，用于校验输入状态等信令的群成员。
+//	- `Signals`: 初始化临时信令的跨节点路由，基于 Redis 发布订阅。
+//	- `Presence`: 初始化在线状态存储，由连接的建立、断开与心跳驱动。
 func NewServiceContext(c config.Config) *ServiceContext {
+	nodeId := c.NodeId
+	if nodeId == "" {
+		nodeId = gateway.DefaultNodeId(c.ListenOn)
+	}
+	rds := redis.MustNewRedis(c.Redisx)
+	presenceStore := presence.NewStore(rds, presence.DefaultTTL)
+	// 路由与在线状态由同一个心跳刷新，使用相同的有效期
+	registry := gateway.NewRedisRegistry(rds, presenceStore.TTL())
+	signalTransport := gateway.NewRedisTransport(c.Redisx, constants.RedisWsSignal)
+
 	return &ServiceContext{
 		Config:                c,
+		NodeId:                nodeId,
+		Redis:                 rds,
+		Registry:              registry,
+		Signals:               gateway.NewRouter(registry, signalTransport),
+		SignalTransport:       signalTransport,
+		Presence:              presenceStore,
+		Subscriptions:         presence.NewSubscriptions(),
+		Im:                    imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
+		Social:                socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
 		MsgChatTransferClient: mqclient.NewMsgChatTransferClient(c.MsgChatTransfer.Addrs, c.MsgChatTransfer.Topic),
 		MsgReadTransferClient: mqclient.NewMsgReadTransferClient(c.MsgReadTransfer.Addrs, c.MsgReadTransfer.Topic),
 		ChatLogModel:          immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
@@ -14,8 +14,9 @@ func (t AckType) ToString() string {
 		return "OnlyAck"
 	case RigorAck:
 		return "RigorAck"
+	case NoAck:
+		return "NoAck"
 	default:
 		panic("Unknown AckType")
 	}
-	return "NoAck"
 }
@@ -10,3 +10,11 @@ type Authentication interface {
 	// UserId 从请求中提取并返回用户ID。
 	UserId(r *http.Request) string
 }
+
+// DeviceAuthentication 是可选的认证扩展接口，用于从请求中提取设备ID。
+//
+// 认证实现如果同时实现了该接口，服务器会优先使用其返回的设备ID区分同一用户的多个设备连接。
+type DeviceAuthentication interface {
+	// DeviceId 从请求中提取并返回设备ID，无法获取时返回空字符串。
+	DeviceId(r *http.Request) string
+}
@@ -75,7 +75,12 @@ func (j *JwtAuth) Authenticate(w http.ResponseWriter, r *http.Request) bool {
 	}
 
 	// 将用户标识符注入到请求上下文中。
-	*r = *r.WithContext(context.WithValue(r.Context(), ctxdata.Identify, claims[ctxdata.Identify]))
+	ctx := context.WithValue(r.Context(), ctxdata.Identify, claims[ctxdata.Identify])
+	// 令牌中携带了设备标识时，一并注入到请求上下文中。
+	if deviceId, ok := claims[ctxdata.DeviceIdentify]; ok {
+		ctx = context.WithValue(ctx, ctxdata.DeviceIdentify, deviceId)
+	}
+	*r = *r.WithContext(ctx)
 	return true
 }
 
@@ -91,3 +96,17 @@ func (j *JwtAuth) Authenticate(w http.ResponseWriter, r *http.Request) bool {
 func (j *JwtAuth) UserId(r *http.Request) string {
 	return ctxdata.GetUId(r.Context())
 }
+
+// DeviceId 从请求的上下文中获取设备标识符。
+//
+// 该方法从请求上下文中提取 Authenticate 注入的设备标识符（来自 JWT 令牌的设备声明），
+// 如果令牌中未携带设备标识，则返回空字符串，由服务器从握手参数中获取。
+//
+// 参数:
+//   - r: HTTP 请求对象，其中包含了请求的所有信息。
+//
+// 返回值:
+//   - string: 返回提取到的设备标识符。
+func (j *JwtAuth) DeviceId(r *http.Request) string {
+	return ctxdata.GetDeviceId(r.Context())
+}
@@ -42,3 +42,15 @@ func (a *WebSocketAuth) UserId(r *http.Request) string {
 	// 如果不存在"userId"参数，生成并返回当前时间的Unix毫秒时间戳作为用户ID
 	return fmt.Sprintf("%v", time.Now().UnixMilli())
 }
+
+// DeviceId 根据请求从 URL 查询参数中提取设备 ID。
+//
+// 参数:
+//   - r: *http.Request
+//     HTTP 请求对象，包含客户端的请求信息。
+//
+// 返回值:
+//   - string: URL 查询参数 "deviceId" 的值，不存在时返回空字符串。
+func (a *WebSocketAuth) DeviceId(r *http.Request) string {
+	return r.URL.Query().Get("deviceId")
+}
@@ -1,9 +1,9 @@
 package websocket
 
 import (
-	"encoding/json"
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
+	"net/http"
 	"net/url"
 )
 
@@ -20,6 +20,7 @@ type client struct {
 	*websocket.Conn            // WebSocket 连接。
 	host            string     // WebSocket 服务器的主机地址。
 	opt             dialOption // WebSocket 连接的拨号选项。
+	codec           Codec      // 与服务器协商的消息编解码器。
 }
 
 // NewClient 创建一个新的 WebSocket 客户端。
@@ -55,6 +56,7 @@ func NewClient(host string, opts ...DialOptions) *client {
 // dial 与 WebSocket 服务器建立连接。
 //
 // 该方法用于与 WebSocket 服务器建立连接，并返回一个 WebSocket 连接实例。
+// 配置了 JSON 以外的编解码器时，通过 Sec-WebSocket-Protocol 头与服务器协商，服务器不支持时使用 JSON 编解码器。
 // 如果连接失败，则返回错误。
 //
 // 返回:
@@ -64,12 +66,30 @@ func (c *client) dial() (*websocket.Conn, error) {
 	// 构造WebSocket连接的URL。
 	u := url.URL{Scheme: "ws", Host: c.host, Path: c.opt.pattern}
 
-	// 使用DefaultDialer进行WebSocket连接。
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), c.opt.header)
+	// 在请求的子协议中加入编解码器的名称。
+	dialer := *websocket.DefaultDialer
+	header := c.opt.header
+	if c.opt.codec.Name() != JSONProtocol {
+		dialer.Subprotocols = []string{c.opt.codec.Name()}
+		if header != nil {
+			header = header.Clone()
+			dialer.Subprotocols = append(dialer.Subprotocols,
+				websocket.Subprotocols(&http.Request{Header: header})...)
+			header.Del("Sec-WebSocket-Protocol")
+		}
+	}
+
+	// 进行WebSocket连接。
+	conn, _, err := dialer.Dial(u.String(), header)
 	if err != nil {
 		return nil, err // 如果连接失败，返回错误。
 	}
 
+	// 服务器未选择该编解码器时使用 JSON 编解码器。
+	c.codec = JSONCodec
+	if conn.Subprotocol() == c.opt.codec.Name() {
+		c.codec = c.opt.codec
+	}
 	return conn, nil // 如果连接成功，返回连接对象。
 }
 
@@ -88,11 +108,11 @@ func (c *client) Close() error {
 
 // Send 序列化并发送消息到 WebSocket。
 //
-// 该方法将消息对象序列化为 JSON 格式，并通过 WebSocket 连接发送。
+// 该方法使用协商的编解码器序列化消息对象，并通过 WebSocket 连接发送。
 // 如果发送失败，会尝试重新连接并重新发送消息。
 //
 // 参数:
-//   - v: 要发送的消息对象，可以是任意类型。
+//   - v: 要发送的消息对象，使用 JSON 以外的编解码器时必须为 Message 或 *Message。
 //
 // 返回:
 //   - error: 发送消息过程中发生的错误（如果有的话）。
@@ -101,32 +121,37 @@ func (c *client) Send(v any) error {
 		return errors.New("connection is nil")
 	}
 	// 序列化消息。
-	data, err := json.Marshal(v)
+	codec := c.codec
+	data, err := codec.Marshal(v)
 	if err != nil {
 		return err
 	}
 	// 发送消息。
-	err = c.Conn.WriteMessage(websocket.TextMessage, data)
+	err = c.Conn.WriteMessage(codec.MessageType(), data)
 	if err == nil {
 		return nil
 	}
-	// 重新连接并重新发送消息。
+	// 重新连接并重新发送消息，重新连接后协商的编解码器可能不同。
 	conn, err := c.dial()
 	if err != nil {
 		return err
 	}
 	c.Conn = conn
-	err = c.Conn.WriteMessage(websocket.TextMessage, data)
-	return err
+	if c.codec != codec {
+		if data, err = c.codec.Marshal(v); err != nil {
+			return err
+		}
+	}
+	return c.Conn.WriteMessage(c.codec.MessageType(), data)
 }
 
 // Read 从 WebSocket 读取消息并反序列化。
 //
-// 该方法从 WebSocket 连接中读取消息，并将其反序列化为指定的对象类型。
+// 该方法从 WebSocket 连接中读取消息，并使用协商的编解码器将其反序列化为指定的对象类型。
 // 如果读取或反序列化过程中发生错误，则返回错误。
 //
 // 参数:
-//   - v: 用于接收反序列化后的消息对象，可以是任意类型。
+//   - v: 用于接收反序列化后的消息对象，使用 JSON 以外的编解码器时必须为 *Message。
 //
 // 返回:
 //   - error: 读取消息过程中发生的错误（如果有的话）。
@@ -140,5 +165,5 @@ func (c *client) Read(v any) error {
 		return err
 	}
 	// 反序列化消息。
-	return json.Unmarshal(msg, v)
+	return c.codec.Unmarshal(msg, v)
 }
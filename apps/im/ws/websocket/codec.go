@@ -0,0 +1,83 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"strings"
+)
+
+const (
+	// JSONProtocol JSON 编解码器的子协议名称，客户端未协商编解码器时默认使用。
+	JSONProtocol = "json"
+	// ProtobufProtocol protobuf 编解码器的子协议名称。
+	ProtobufProtocol = "protobuf"
+)
+
+// ErrCodecMessage 编解码器只能编解码 Message 类型的消息。
+var ErrCodecMessage = errors.New("websocket: codec only supports websocket.Message")
+
+// Codec 定义了 WebSocket 消息的编解码器。
+//
+// 客户端通过 Sec-WebSocket-Protocol 头协商编解码器，服务器与 Client 使用协商的编解码器读写消息。
+type Codec interface {
+	// Name 返回编解码器的子协议名称。
+	Name() string
+	// MessageType 返回编码后数据帧的 WebSocket 消息类型（文本帧或二进制帧）。
+	MessageType() int
+	// Marshal 编码消息。
+	Marshal(v any) ([]byte, error)
+	// Unmarshal 解码消息。
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec 使用 JSON 文本帧的编解码器，消息数据解码为 map，由路由处理函数使用 mapstructure 解码。
+var JSONCodec Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return JSONProtocol }
+
+func (jsonCodec) MessageType() int { return websocket.TextMessage }
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// negotiate 根据请求的 Sec-WebSocket-Protocol 头协商消息编解码器。
+//
+// 按客户端给出的顺序选择第一个服务器支持的编解码器，未协商时使用 JSON 编解码器。
+// 编解码器的名称会从请求头中移除，剩余的子协议（例如浏览器客户端放在其中的鉴权令牌）保留给鉴权使用。
+//
+// 参数:
+//   - r: HTTP 请求对象。
+//
+// 返回:
+//   - Codec: 协商的编解码器。
+//   - string: 响应的 Sec-WebSocket-Protocol 头，协商了编解码器时为其名称，否则为剩余的子协议。
+func (s *Server) negotiate(r *http.Request) (Codec, string) {
+	var (
+		codec Codec
+		rest  []string
+	)
+	for _, protocol := range websocket.Subprotocols(r) {
+		if c, ok := s.opt.codecs[protocol]; ok {
+			if codec == nil {
+				codec = c
+			}
+			continue
+		}
+		rest = append(rest, protocol)
+	}
+
+	if len(rest) > 0 {
+		r.Header.Set("Sec-WebSocket-Protocol", strings.Join(rest, ", "))
+	} else {
+		r.Header.Del("Sec-WebSocket-Protocol")
+	}
+	if codec != nil {
+		return codec, codec.Name()
+	}
+	return JSONCodec, strings.Join(rest, ", ")
+}
@@ -1,12 +1,17 @@
 package websocket
 
 import (
+	"errors"
 	"github.com/gorilla/websocket"
+	"io"
 	"net/http"
 	"sync"
 	"time"
 )
 
+// ErrMessageTooLarge 客户端发送的消息超过服务器允许的最大字节数。
+var ErrMessageTooLarge = errors.New("websocket: message too large")
+
 // Conn 表示 WebSocket 连接。
 //
 // 该结构体定义了一个WebSocket连接的主要属性和状态，包括用户ID、WebSocket连接实例、
@@ -16,6 +21,8 @@ import (
 // 字段:
 //   - idleMu: 连接空闲状态的互斥锁，用于保护空闲时间的读写操作。
 //   - Uid: 用户标识符，用于标识与该连接关联的用户。
+//   - DeviceId: 设备标识符，同一用户的多个设备连接以此区分。
+//   - Platform: 设备所属平台（如 ios、android、web、pc），用于同平台互踢策略。
 //   - wsConn: WebSocket连接实例，表示与客户端的实际WebSocket连接。
 //   - s: 连接所属的WebSocket服务器，用于访问服务器相关的功能和状态。
 //   - idle: 连接的空闲时间，用于检测连接的活动状态。
@@ -23,13 +30,21 @@ import (
 //   - messageMu: 消息队列的互斥锁，用于保护消息队列的读写操作。
 //   - readMessage: 读消息队列，存储尚未处理的消息。
 //   - readMessageSeq: 读消息队列的序列化映射，用于按序号存储消息。
+//   - pushMu: 推送消息确认表的互斥锁。
+//   - pushes: 已推送给客户端、等待客户端确认送达的消息，以消息 ID 为键。
+//   - queueMu: 发送队列溢出处理的互斥锁。
+//   - outbound: 有界的发送队列，由连接的写协程依次写出，慢连接不会阻塞发送方。
 //   - message: 消息通道，用于接收和发送消息。
+//   - codec: 连接协商的消息编解码器。
+//   - closeOnce: 保证连接只关闭一次。
 //   - done: 关闭连接时的信号通道，用于通知连接的结束。
 type Conn struct {
-	idleMu sync.Mutex
-	Uid    string
-	wsConn *websocket.Conn
-	s      *Server
+	idleMu   sync.Mutex
+	Uid      string
+	DeviceId string
+	Platform string
+	wsConn   *websocket.Conn
+	s        *Server
 
 	idle              time.Time
 	maxConnectionIdle time.Duration
@@ -38,8 +53,17 @@ type Conn struct {
 	readMessage    []*Message          // 读消息队列
 	readMessageSeq map[string]*Message // 读消息队列序列化
 
-	message chan *Message
-	done    chan struct{}
+	pushMu sync.Mutex
+	pushes map[string]*pendingPush // 等待客户端确认的推送消息
+
+	queueMu  sync.Mutex
+	outbound chan outboundFrame // 发送队列
+
+	codec Codec // 消息编解码器
+
+	message   chan *Message
+	closeOnce sync.Once
+	done      chan struct{}
 }
 
 // appendMsgMq 将消息添加到消息队列中。
@@ -81,29 +105,26 @@ func (c *Conn) appendMsgMq(msg *Message) {
 
 // Close 关闭 WebSocket 连接。
 //
-// 该方法关闭WebSocket连接并通知所有相关操作停止。
-// 如果连接已经关闭，则不会重复关闭。关闭操作通过信号通道通知。
-// 关闭操作返回WebSocket连接关闭的错误（如果有的话）。
+// 该方法通知所有相关操作停止，如果连接已经关闭，则不会重复关闭。
+// 底层连接由写协程在写出发送队列中剩余的消息后关闭，因此关闭前发送的通知（例如被踢下线）仍能送达。
 //
 // 返回:
-//   - error: 关闭WebSocket连接时发生的错误，如果成功关闭则返回nil。
+//   - error: 始终为 nil，底层连接的关闭错误由写协程处理。
 func (c *Conn) Close() error {
-	select {
-	case <-c.done:
-		// 如果 c.done 通道已经关闭，执行此分支，什么都不做。
-	default:
-		// 如果 c.done 通道未关闭，执行此分支，关闭该通道。
+	c.closeOnce.Do(func() {
 		close(c.done)
-	}
-
-	// 关闭 websocket 连接
-	return c.wsConn.Close()
+		// 等待确认的推送消息无法再送达
+		c.s.failPushes(c)
+	})
+	return nil
 }
 
 // ReadMessage 从 WebSocket 连接中读取消息。
 //
 // 该方法从WebSocket连接中读取消息，并重置连接的空闲时间。
 // 如果读取消息时发生错误，则返回该错误。
+// 消息（压缩的消息按解压后的大小计算）超过服务器允许的最大字节数时，丢弃该消息并返回 ErrMessageTooLarge，
+// 之后仍可继续读取下一条消息。
 // 空闲时间用于管理连接的活跃状态。
 //
 // 返回:
@@ -111,7 +132,7 @@ func (c *Conn) Close() error {
 //   - p: 读取到的消息内容。
 //   - err: 读取消息时发生的错误，如果没有错误则返回nil。
 func (c *Conn) ReadMessage() (messageType int, p []byte, err error) {
-	messageType, p, err = c.wsConn.ReadMessage()
+	messageType, p, err = c.readLimited()
 	c.idleMu.Lock()
 	defer c.idleMu.Unlock()
 	// 置零，表示当前连接不再空闲
@@ -119,9 +140,44 @@ func (c *Conn) ReadMessage() (messageType int, p []byte, err error) {
 	return
 }
 
-// WriteMessage 向 WebSocket 连接中写入消息。
+// readLimited 读取一条不超过最大字节数的消息，过大的消息读取后丢弃，不占用内存。
+func (c *Conn) readLimited() (int, []byte, error) {
+	messageType, r, err := c.wsConn.NextReader()
+	if err != nil {
+		return messageType, nil, err
+	}
+	limit := c.s.opt.maxMessageSize
+	if limit <= 0 {
+		p, err := io.ReadAll(r)
+		return messageType, p, err
+	}
+
+	p, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return messageType, nil, err
+	}
+	if int64(len(p)) > limit {
+		// 丢弃消息的剩余部分，以便继续读取下一条消息
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return messageType, nil, err
+		}
+		return messageType, nil, ErrMessageTooLarge
+	}
+	return messageType, p, nil
+}
+
+// setWriteCompression 根据消息大小决定是否压缩，只由连接的写协程调用。
+//
+// 只有连接协商了 permessage-deflate 时才会压缩。
+func (c *Conn) setWriteCompression(size int) {
+	if c.s.opt.compression {
+		c.wsConn.EnableWriteCompression(size >= c.s.opt.compressThreshold)
+	}
+}
+
+// writeMessage 向 WebSocket 连接中写入消息，只由连接的写协程调用。
 //
-// 该方法将消息写入WebSocket连接，并更新连接的空闲时间。
+// 该方法在写超时内将消息写入WebSocket连接，并更新连接的空闲时间。
 // 如果写入消息时发生错误，则返回该错误。
 // 空闲时间用于管理连接的活跃状态。
 //
@@ -131,10 +187,15 @@ func (c *Conn) ReadMessage() (messageType int, p []byte, err error) {
 //
 // 返回:
 //   - error: 写入消息时发生的错误，如果成功写入则返回nil。
-func (c *Conn) WriteMessage(messageType int, data []byte) error {
+func (c *Conn) writeMessage(messageType int, data []byte) error {
+	if c.s.opt.writeTimeout > 0 {
+		c.wsConn.SetWriteDeadline(time.Now().Add(c.s.opt.writeTimeout))
+	}
+	c.setWriteCompression(len(data))
+	err := c.wsConn.WriteMessage(messageType, data)
+
 	c.idleMu.Lock()
 	defer c.idleMu.Unlock()
-	err := c.wsConn.WriteMessage(messageType, data)
 	// 更新空闲时间，表示当前连接空闲
 	c.idle = time.Now()
 	return err
@@ -186,7 +247,7 @@ func (c *Conn) keepalive() {
 // NewConn 创建一个新的 WebSocket 连接。
 //
 // 该函数用于创建一个新的 WebSocket 连接，并返回一个包含连接信息的 `Conn` 对象。
-// 它使用服务器的升级器将 HTTP 请求升级为 WebSocket 连接，并根据请求头中的 "Sec-WebSocket-Protocol" 头设置响应头。
+// 它根据请求头中的 "Sec-WebSocket-Protocol" 头协商消息编解码器并设置响应头，然后使用服务器的升级器将 HTTP 请求升级为 WebSocket 连接。
 // 连接成功后，初始化连接对象的相关字段，并启动一个后台协程用于保持连接的活动状态。
 //
 // 参数:
@@ -203,8 +264,9 @@ func NewConn(s *Server, w http.ResponseWriter, r *http.Request) *Conn {
 	// 初始化响应头，用于设置 WebSocket 协议升级的响应。
 	var responseHeader http.Header
 
-	// 如果请求指定了 Sec-WebSocket-Protocol 头，则在响应中进行相应设置。
-	if protocol := r.Header.Get("Sec-WebSocket-Protocol"); protocol != "" {
+	// 协商消息编解码器，如果请求指定了 Sec-WebSocket-Protocol 头，则在响应中进行相应设置。
+	codec, protocol := s.negotiate(r)
+	if protocol != "" {
 		responseHeader = http.Header{
 			"Sec-WebSocket-Protocol": []string{protocol},
 		}
@@ -217,6 +279,9 @@ func NewConn(s *Server, w http.ResponseWriter, r *http.Request) *Conn {
 		s.Errorf("Error upgrading connection: %s", err)
 		return nil
 	}
+	if s.opt.compression {
+		c.SetCompressionLevel(s.opt.compressionLevel)
+	}
 
 	// 初始化 Conn 实例，并设置相关属性。
 	// 包括 WebSocket 连接、服务器实例、连接空闲时间、最大空闲时间、消息队列等。
@@ -227,11 +292,17 @@ func NewConn(s *Server, w http.ResponseWriter, r *http.Request) *Conn {
 		maxConnectionIdle: s.opt.maxConnectionIdle,
 		readMessage:       make([]*Message, 0, 2),
 		readMessageSeq:    make(map[string]*Message, 2),
+		pushes:            make(map[string]*pendingPush),
+		outbound:          make(chan outboundFrame, s.opt.writeQueueSize),
+		codec:             codec,
 		message:           make(chan *Message, 1),
 		done:              make(chan struct{}),
 	}
 
 	// 启动后台协程执行心跳检测，以保持连接的活跃状态。
 	go conn.keepalive()
+	// 启动写协程，依次写出发送队列中的消息。
+	s.writers.Add(1)
+	go conn.writeLoop()
 	return conn
 }
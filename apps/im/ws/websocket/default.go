@@ -1,13 +1,26 @@
 package websocket
 
 import (
+	"compress/flate"
 	"math"
 	"time"
 )
 
+// DefaultDeviceId 未提供设备 ID 的连接使用的设备 ID，同一用户未提供设备 ID 的连接视为同一设备。
+const DefaultDeviceId = "default"
+
 const (
 	defaultMaxConnectionIdle = time.Duration(math.MaxInt64)
 	defaultAckTimeout        = 30 * time.Second
+	defaultPushAckTimeout    = 5 * time.Second
 	defaultSendErrCount      = 1
 	defaultConcurrency       = 10
+	defaultWriteQueueSize    = 256
+	defaultWriteTimeout      = 10 * time.Second
+	defaultShutdownTimeout   = 10 * time.Second
+	defaultDrainTimeout      = 5 * time.Second
+	defaultReconnectDelay    = time.Second
+	defaultCompressionLevel  = flate.BestSpeed
+	defaultCompressThreshold = 1024
+	defaultMaxMessageSize    = 1 << 20
 )
@@ -0,0 +1,162 @@
+package websocket
+
+import (
+	"github.com/zeromicro/go-zero/core/threading"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// UndeliveredFunc 推送消息未送达时的回调。
+//
+// 在多次重传后仍未收到客户端的确认，或者连接在确认前断开时调用，
+// 可用于将消息保存下来，待设备重新连接后补发或由客户端离线同步。
+type UndeliveredFunc func(srv *Server, conn *Conn, msg *Message)
+
+// pushIdPrefix 服务端为推送消息生成的消息 ID 前缀，避免与客户端生成的消息 ID 冲突。
+const pushIdPrefix = "push-"
+
+// pendingPush 等待客户端确认送达的推送消息。
+type pendingPush struct {
+	msg   *Message
+	timer *time.Timer
+}
+
+// Push 向指定的连接推送消息，并等待客户端确认送达。
+//
+// 未开启推送确认时等同于 Send。开启后，每个连接上的推送消息都会被记录，
+// 客户端收到消息后需回复 FrameAck 帧（Id 为推送消息的 Id）进行确认；
+// 超时未确认时按退避时间重传，重传次数超过 sendErrCount 或者连接断开时，
+// 消息被视为未送达并交给 UndeliveredFunc 处理。
+// 消息未设置 Id 时由服务器生成，同一次推送的所有连接使用相同的 Id。
+//
+// 参数:
+//   - msg: 要推送的消息。
+//   - conns: 要推送消息的连接列表。
+//
+// 返回:
+//   - error: 首次发送时出现的错误，失败的消息仍会按重传策略重试。
+func (s *Server) Push(msg *Message, conns ...*Conn) error {
+	if !s.opt.pushAck {
+		return s.Send(msg, conns...)
+	}
+	if len(conns) == 0 {
+		return nil
+	}
+
+	id := msg.Id
+	if id == "" {
+		id = pushIdPrefix + strconv.FormatUint(atomic.AddUint64(&s.pushSeq, 1), 36)
+	}
+
+	var lastErr error
+	for _, conn := range conns {
+		m := *msg
+		m.Id = id
+		m.ErrCount = 0
+		// 记录消息副本，重传时修改的错误计数不影响本次发送
+		tracked := m
+		s.trackPush(conn, &tracked)
+
+		if err := s.Send(&m, conn); err != nil {
+			s.Errorf("push send err: %v, uid: %v, device: %v, mid: %v", err, conn.Uid, conn.DeviceId, id)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// trackPush 记录等待确认的推送消息，并启动重传计时器。
+func (s *Server) trackPush(conn *Conn, msg *Message) {
+	conn.pushMu.Lock()
+	defer conn.pushMu.Unlock()
+
+	if p, ok := conn.pushes[msg.Id]; ok {
+		// 重复推送同一条消息，以最新的一次为准
+		p.timer.Stop()
+	}
+	conn.pushes[msg.Id] = &pendingPush{
+		msg: msg,
+		timer: time.AfterFunc(s.pushBackoff(0), func() {
+			s.retransmit(conn, msg.Id)
+		}),
+	}
+}
+
+// ackPush 处理客户端对推送消息的确认。
+//
+// 返回:
+//   - bool: 该确认是否对应一条等待确认的推送消息。
+func (s *Server) ackPush(conn *Conn, id string) bool {
+	conn.pushMu.Lock()
+	defer conn.pushMu.Unlock()
+
+	p, ok := conn.pushes[id]
+	if !ok {
+		return false
+	}
+	p.timer.Stop()
+	delete(conn.pushes, id)
+	return true
+}
+
+// retransmit 重传未被确认的推送消息，超过重传次数后放弃并回调 UndeliveredFunc。
+func (s *Server) retransmit(conn *Conn, id string) {
+	conn.pushMu.Lock()
+	p, ok := conn.pushes[id]
+	if !ok {
+		conn.pushMu.Unlock()
+		return
+	}
+
+	p.msg.ErrCount++
+	if p.msg.ErrCount > s.opt.sendErrCount {
+		delete(conn.pushes, id)
+		conn.pushMu.Unlock()
+
+		s.Infof("push undelivered, uid: %v, device: %v, mid: %v, errCount: %v", conn.Uid, conn.DeviceId, id, p.msg.ErrCount)
+		s.undelivered(conn, p.msg)
+		return
+	}
+	p.timer.Reset(s.pushBackoff(p.msg.ErrCount))
+	msg := *p.msg
+	conn.pushMu.Unlock()
+
+	if err := s.Send(&msg, conn); err != nil {
+		s.Errorf("push retransmit err: %v, uid: %v, device: %v, mid: %v", err, conn.Uid, conn.DeviceId, id)
+	}
+}
+
+// failPushes 连接断开时，将全部等待确认的推送消息视为未送达。
+func (s *Server) failPushes(conn *Conn) {
+	conn.pushMu.Lock()
+	pushes := conn.pushes
+	conn.pushes = make(map[string]*pendingPush)
+	conn.pushMu.Unlock()
+
+	for _, p := range pushes {
+		p.timer.Stop()
+		s.undelivered(conn, p.msg)
+	}
+}
+
+// undelivered 异步执行未送达回调，避免阻塞连接的读写与关闭流程。
+func (s *Server) undelivered(conn *Conn, msg *Message) {
+	if s.opt.undelivered == nil {
+		return
+	}
+	s.callbacks.Add(1)
+	threading.GoSafe(func() {
+		defer s.callbacks.Done()
+		s.opt.undelivered(s, conn, msg)
+	})
+}
+
+// pushBackoff 计算第 n 次重传前等待确认的时间，每次重传等待时间翻倍，最长为 ackTimeout。
+func (s *Server) pushBackoff(n int) time.Duration {
+	d := s.opt.pushAckTimeout << uint(n)
+	if d <= 0 || d > s.opt.ackTimeout {
+		d = s.opt.ackTimeout
+	}
+	return d
+}
@@ -13,6 +13,7 @@ type DialOptions func(option *dialOption)
 type dialOption struct {
 	header  http.Header // HTTP 头部
 	pattern string      // 连接路径模式
+	codec   Codec       // 消息编解码器
 }
 
 // newDialOptions 创建一个具有默认值的新的 dialOption 结构体，并根据传入的选项进行配置。
@@ -29,6 +30,7 @@ func newDialOptions(opts ...DialOptions) dialOption {
 	o := dialOption{
 		header:  nil,
 		pattern: "/ws",
+		codec:   JSONCodec,
 	}
 	// 应用传入的选项
 	for _, opt := range opts {
@@ -66,3 +68,19 @@ func WithClientHeader(header http.Header) DialOptions {
 		opt.header = header
 	}
 }
+
+// WithClientCodec 返回一个设置消息编解码器的 DialOptions 函数。
+//
+// 该函数返回一个 DialOptions 函数，连接时通过 Sec-WebSocket-Protocol 头与服务器协商编解码器，
+// 服务器不支持该编解码器时使用 JSON 编解码器。
+//
+// 参数:
+//   - codec: 消息编解码器，例如 NewProtoCodec 创建的 protobuf 编解码器。
+//
+// 返回:
+//   - DialOptions: 配置消息编解码器的函数。
+func WithClientCodec(codec Codec) DialOptions {
+	return func(opt *dialOption) {
+		opt.codec = codec
+	}
+}
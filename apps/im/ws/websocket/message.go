@@ -6,11 +6,12 @@ import "time"
 type FrameType uint8
 
 const (
-	FrameData  FrameType = 0x0 // 数据帧
-	FramePing  FrameType = 0x1 // Ping 帧
-	FrameAck   FrameType = 0x2 // Ack 帧
-	FrameNoAck FrameType = 0x3 // 无 Ack 帧
-	FrameErr   FrameType = 0x9 // 错误帧
+	FrameData   FrameType = 0x0 // 数据帧
+	FramePing   FrameType = 0x1 // Ping 帧
+	FrameAck    FrameType = 0x2 // Ack 帧
+	FrameNoAck  FrameType = 0x3 // 无 Ack 帧
+	FrameGoAway FrameType = 0x7 // 服务器即将关闭，客户端应断开并重新连接
+	FrameErr    FrameType = 0x9 // 错误帧
 
 	// 其他可能的帧类型（已注释）
 	//FrameHeaders      FrameType = 0x1
@@ -18,7 +19,6 @@ const (
 	//FrameRSTStream    FrameType = 0x3
 	//FrameSettings     FrameType = 0x4
 	//FramePushPromise  FrameType = 0x5
-	//FrameGoAway       FrameType = 0x7
 	//FrameWindowUpdate FrameType = 0x8
 	//FrameContinuation FrameType = 0x9
 )
@@ -54,6 +54,50 @@ func NewMessage(formId string, data interface{}) *Message {
 	}
 }
 
+// GoAway 服务器关闭前发送给客户端的下线通知。
+type GoAway struct {
+	Reason     string `json:"reason"`     // 关闭原因
+	Reconnect  bool   `json:"reconnect"`  // 客户端是否应重新连接
+	RetryAfter int64  `json:"retryAfter"` // 建议重新连接前等待的毫秒数，已加入随机抖动以分散重连
+}
+
+// NewGoAwayMessage 创建一个服务器即将关闭的通知消息。
+//
+// 参数:
+//   - reason: 关闭原因。
+//   - retryAfter: 建议客户端重新连接前等待的时间。
+//
+// 返回值:
+//   - *Message: 返回创建好的下线通知消息。
+func NewGoAwayMessage(reason string, retryAfter time.Duration) *Message {
+	return &Message{
+		FrameType: FrameGoAway,
+		Data: &GoAway{
+			Reason:     reason,
+			Reconnect:  true,
+			RetryAfter: retryAfter.Milliseconds(),
+		},
+	}
+}
+
+// ErrCode 错误帧的错误码。
+type ErrCode int
+
+const (
+	// ErrCodeMessageTooLarge 客户端发送的消息超过服务器允许的大小，与 WebSocket 关闭码 1009（Message Too Big）一致。
+	ErrCodeMessageTooLarge ErrCode = 1009
+)
+
+// Error 携带错误码的错误帧数据。
+type Error struct {
+	Code ErrCode `json:"code"` // 错误码
+	Msg  string  `json:"msg"`  // 错误信息
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
 // NewErrMessage 创建一个新的错误消息。
 //
 // 该函数用于创建一个包含错误信息的消息对象。消息的类型被设置为 `FrameErr`，
@@ -70,3 +114,23 @@ func NewErrMessage(err error) *Message {
 		Data:      err.Error(),
 	}
 }
+
+// NewErrCodeMessage 创建一个携带错误码的错误消息。
+//
+// 该函数用于创建一个类型为 `FrameErr` 的消息，`Data` 字段为 *Error，客户端可以根据错误码进行处理。
+//
+// 参数:
+//   - code: 错误码。
+//   - err: 错误对象，其错误信息作为 Error.Msg。
+//
+// 返回值:
+//   - *Message: 返回创建好的错误消息对象。
+func NewErrCodeMessage(code ErrCode, err error) *Message {
+	return &Message{
+		FrameType: FrameErr,
+		Data: &Error{
+			Code: code,
+			Msg:  err.Error(),
+		},
+	}
+}
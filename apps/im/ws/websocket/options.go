@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"compress/flate"
 	"easy-chat/apps/im/ws/websocket/auth"
 	"time"
 )
@@ -8,6 +9,9 @@ import (
 // ServerOptions 定义 WebSocket 服务器的选项配置函数。
 type ServerOptions func(opt *websocketOption)
 
+// ConnHook 连接生命周期回调函数，在连接建立或断开时调用。
+type ConnHook func(srv *Server, conn *Conn)
+
 type websocketOption struct {
 	auth.Authentication        // WebSocket 服务器的身份认证设置
 	patten              string // WebSocket 路由模式
@@ -16,9 +20,33 @@ type websocketOption struct {
 	ackTimeout   time.Duration // 消息确认超时时间
 	sendErrCount int           // 发送错误次数限制
 
+	compression       bool  // 是否协商 permessage-deflate 压缩
+	compressionLevel  int   // 压缩级别
+	compressThreshold int   // 达到该字节数的消息才压缩
+	maxMessageSize    int64 // 客户端消息的最大字节数
+
+	pushAck        bool            // 是否开启推送消息的送达确认
+	pushAckTimeout time.Duration   // 推送消息首次等待确认的时间
+	undelivered    UndeliveredFunc // 推送消息未送达时的回调
+
 	maxConnectionIdle time.Duration // 最大连接空闲时间
 
+	kickSamePlatform bool // 同一用户在相同平台上登入新设备时，是否踢下线其他设备
+
+	connectHooks []ConnHook // 连接建立后的回调
+	closeHooks   []ConnHook // 连接断开后的回调
+
 	concurrency int // 群消息并发处理量级
+
+	writeQueueSize int            // 每个连接发送队列的容量
+	overflowPolicy OverflowPolicy // 发送队列已满时的处理策略
+	writeTimeout   time.Duration  // 单次写入的超时时间
+
+	shutdownTimeout time.Duration // Stop 优雅关闭的最长时间
+	drainTimeout    time.Duration // 关闭前等待客户端确认推送、消息处理完成的最长时间
+	reconnectDelay  time.Duration // 下线通知中建议客户端重连前等待的基础时间
+
+	codecs map[string]Codec // 支持的消息编解码器，以子协议名称为键
 }
 
 // newWebsocketServerOption 创建一个新的 websocketOption 实例。
@@ -35,9 +63,20 @@ func newWebsocketServerOption(opts ...ServerOptions) websocketOption {
 		Authentication:    new(auth.WebSocketAuth),
 		maxConnectionIdle: defaultMaxConnectionIdle,
 		ackTimeout:        defaultAckTimeout,
+		pushAckTimeout:    defaultPushAckTimeout,
 		sendErrCount:      defaultSendErrCount,
+		compressionLevel:  defaultCompressionLevel,
+		compressThreshold: defaultCompressThreshold,
+		maxMessageSize:    defaultMaxMessageSize,
 		patten:            "/ws",
 		concurrency:       defaultConcurrency,
+		writeQueueSize:    defaultWriteQueueSize,
+		overflowPolicy:    OverflowDisconnect,
+		writeTimeout:      defaultWriteTimeout,
+		shutdownTimeout:   defaultShutdownTimeout,
+		drainTimeout:      defaultDrainTimeout,
+		reconnectDelay:    defaultReconnectDelay,
+		codecs:            map[string]Codec{JSONProtocol: JSONCodec},
 	}
 	for _, opt := range opts {
 		opt(&o)
@@ -90,6 +129,43 @@ func WithServerAck(ack AckType) ServerOptions {
 	}
 }
 
+// WithServerCompression 开启 permessage-deflate 压缩。
+//
+// 该函数返回一个 ServerOptions 函数。客户端支持时，连接协商 permessage-deflate 扩展，
+// 达到 threshold 字节的消息压缩后发送，较小的消息压缩收益有限，仍不压缩发送；客户端发送的压缩消息均可读取。
+//
+// 参数:
+//   - level: 压缩级别，取值范围为 flate.HuffmanOnly 到 flate.BestCompression，超出范围时保持默认值 flate.BestSpeed。
+//   - threshold: 压缩的最小字节数，小于等于 0 时压缩所有消息，默认为 1024。
+//
+// 返回:
+//   - ServerOptions: 配置消息压缩的函数。
+func WithServerCompression(level, threshold int) ServerOptions {
+	return func(opt *websocketOption) {
+		opt.compression = true
+		if level >= flate.HuffmanOnly && level <= flate.BestCompression {
+			opt.compressionLevel = level
+		}
+		opt.compressThreshold = threshold
+	}
+}
+
+// WithServerMaxMessageSize 配置客户端消息的最大字节数。
+//
+// 该函数返回一个 ServerOptions 函数。超过该大小的消息（压缩的消息按解压后的大小计算）被丢弃，
+// 服务器返回错误码为 ErrCodeMessageTooLarge 的错误帧，连接保持可用。默认为 1 MB。
+//
+// 参数:
+//   - size: 消息的最大字节数，小于等于 0 时不限制。
+//
+// 返回:
+//   - ServerOptions: 配置消息最大字节数的函数。
+func WithServerMaxMessageSize(size int64) ServerOptions {
+	return func(opt *websocketOption) {
+		opt.maxMessageSize = size
+	}
+}
+
 // WithServerSendErrCount 配置发送错误次数限制。
 //
 // 该函数返回一个 ServerOptions 函数，用于设置 WebSocket 服务器的发送错误次数限制。
@@ -105,6 +181,122 @@ func WithServerSendErrCount(sendErrCount int) ServerOptions {
 	}
 }
 
+// WithServerPushAck 开启推送消息的送达确认。
+//
+// 该函数返回一个 ServerOptions 函数，开启后通过 Server.Push 推送的消息需要客户端回复 FrameAck 帧确认，
+// 未确认的消息按退避时间重传，重传次数超过 sendErrCount 或连接断开时调用 undelivered。
+//
+// 参数:
+//   - undelivered: 推送消息未送达时的回调，可以为 nil。
+//
+// 返回:
+//   - ServerOptions: 配置推送送达确认的函数。
+func WithServerPushAck(undelivered UndeliveredFunc) ServerOptions {
+	return func(opt *websocketOption) {
+		opt.pushAck = true
+		opt.undelivered = undelivered
+	}
+}
+
+// WithServerPushAckTimeout 配置推送消息首次等待确认的时间。
+//
+// 该函数返回一个 ServerOptions 函数，之后每次重传的等待时间翻倍，最长不超过消息确认超时时间。
+//
+// 参数:
+//   - timeout: 首次等待确认的时间。
+//
+// 返回:
+//   - ServerOptions: 配置推送确认等待时间的函数。
+func WithServerPushAckTimeout(timeout time.Duration) ServerOptions {
+	return func(opt *websocketOption) {
+		if timeout > 0 {
+			opt.pushAckTimeout = timeout
+		}
+	}
+}
+
+// WithServerWriteQueue 配置每个连接的发送队列。
+//
+// 该函数返回一个 ServerOptions 函数，发送给连接的消息先放入有界的发送队列，由连接的写协程依次写出；
+// 队列已满说明客户端消费过慢，按 policy 丢弃最早的消息或断开该连接。
+// 默认容量为 256，默认断开消费过慢的连接。
+//
+// 参数:
+//   - size: 发送队列的容量，小于等于 0 时保持默认值。
+//   - policy: 发送队列已满时的处理策略。
+//
+// 返回:
+//   - ServerOptions: 配置发送队列的函数。
+func WithServerWriteQueue(size int, policy OverflowPolicy) ServerOptions {
+	return func(opt *websocketOption) {
+		if size > 0 {
+			opt.writeQueueSize = size
+		}
+		opt.overflowPolicy = policy
+	}
+}
+
+// WithServerWriteTimeout 配置单次写入的超时时间。
+//
+// 该函数返回一个 ServerOptions 函数，写入超时的连接会被关闭，默认为 10 秒。
+//
+// 参数:
+//   - timeout: 写入超时时间，小于等于 0 时不设置超时。
+//
+// 返回:
+//   - ServerOptions: 配置写入超时时间的函数。
+func WithServerWriteTimeout(timeout time.Duration) ServerOptions {
+	return func(opt *websocketOption) {
+		opt.writeTimeout = timeout
+	}
+}
+
+// WithServerCodecs 配置服务器支持的消息编解码器。
+//
+// 该函数返回一个 ServerOptions 函数。客户端在 Sec-WebSocket-Protocol 头中给出编解码器的名称进行协商，
+// 未协商的连接使用 JSON 编解码器，JSON 编解码器始终可用。
+//
+// 参数:
+//   - codecs: 额外支持的编解码器，例如 NewProtoCodec 创建的 protobuf 编解码器。
+//
+// 返回:
+//   - ServerOptions: 配置消息编解码器的函数。
+func WithServerCodecs(codecs ...Codec) ServerOptions {
+	return func(opt *websocketOption) {
+		for _, codec := range codecs {
+			opt.codecs[codec.Name()] = codec
+		}
+	}
+}
+
+// WithServerShutdown 配置优雅关闭。
+//
+// 该函数返回一个 ServerOptions 函数。关闭时服务器先向所有连接发送下线通知，
+// 在 drainTimeout 内等待客户端确认已推送的消息、已读取的消息处理完成，然后关闭连接；
+// Stop 整个关闭过程最长持续 shutdownTimeout。
+// 下线通知建议客户端在 reconnectDelay 到其两倍之间的随机时间后重新连接，避免同时重连。
+//
+// 参数:
+//   - shutdownTimeout: Stop 优雅关闭的最长时间，小于等于 0 时保持默认值 10 秒。
+//   - drainTimeout: 关闭连接前的等待时间，小于等于 0 时保持默认值 5 秒。
+//   - reconnectDelay: 建议重连前等待的基础时间，小于 0 时保持默认值 1 秒。
+//
+// 返回:
+//   - ServerOptions: 配置优雅关闭的函数。
+func WithServerShutdown(shutdownTimeout, drainTimeout, reconnectDelay time.Duration) ServerOptions {
+	return func(opt *websocketOption) {
+		if shutdownTimeout > 0 {
+			opt.shutdownTimeout = shutdownTimeout
+		}
+		if drainTimeout > 0 {
+			opt.drainTimeout = drainTimeout
+		}
+		if reconnectDelay >= 0 {
+			opt.reconnectDelay = reconnectDelay
+		}
+	}
+}
+
 // WithWebsocketMaxConnectionIdle 配置最大连接空闲时间。
 //
 // 该函数返回一个 ServerOptions 函数，用于设置 WebSocket 服务器的最大连接空闲时间。
@@ -122,3 +314,49 @@ func WithWebsocketMaxConnectionIdle(duration time.Duration) ServerOptions {
 		}
 	}
 }
+
+// WithServerKickSamePlatform 开启同平台互踢策略。
+//
+// 该函数返回一个 ServerOptions 函数，开启后同一用户在相同平台（如两台手机）上登入新设备时，
+// 该平台上其他设备的连接会收到下线通知并被关闭；不同平台的设备（如手机与桌面端）仍可同时在线。
+// 默认不开启，所有设备均可同时在线。
+//
+// 返回:
+//   - ServerOptions: 配置同平台互踢策略的函数。
+func WithServerKickSamePlatform() ServerOptions {
+	return func(opt *websocketOption) {
+		opt.kickSamePlatform = true
+	}
+}
+
+// WithServerConnectHook 添加连接建立后的回调。
+//
+// 该函数返回一个 ServerOptions 函数，回调在连接通过鉴权并完成登记后调用，
+// 可用于登记连接路由、在线状态等。可以多次调用以添加多个回调。
+//
+// 参数:
+//   - hook: 连接建立后的回调函数。
+//
+// 返回:
+//   - ServerOptions: 配置连接建立回调的函数。
+func WithServerConnectHook(hook ConnHook) ServerOptions {
+	return func(opt *websocketOption) {
+		opt.connectHooks = append(opt.connectHooks, hook)
+	}
+}
+
+// WithServerCloseHook 添加连接断开后的回调。
+//
+// 该函数返回一个 ServerOptions 函数，回调在连接从服务器中移除后调用，
+// 包括读取出错、空闲超时以及被同平台的其他设备踢下线。同一设备重连替换旧连接时不会调用。
+//
+// 参数:
+//   - hook: 连接断开后的回调函数。
+//
+// 返回:
+//   - ServerOptions: 配置连接断开回调的函数。
+func WithServerCloseHook(hook ConnHook) ServerOptions {
+	return func(opt *websocketOption) {
+		opt.closeHooks = append(opt.closeHooks, hook)
+	}
+}
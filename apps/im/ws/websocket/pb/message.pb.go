@@ -0,0 +1,429 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v5.26.0
+// source: apps/im/ws/websocket/message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Message 是 websocket.Message 的二进制帧格式，用于 protobuf 编解码器。
+//
+// 送达确认帧（FrameAck）只使用 id 与 ackSeq，不携带数据。
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 帧类型
+	FrameType uint32 `protobuf:"varint,1,opt,name=frameType,proto3" json:"frameType,omitempty"`
+	// 消息 ID
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Ack 序列号
+	AckSeq int64 `protobuf:"varint,3,opt,name=ackSeq,proto3" json:"ackSeq,omitempty"`
+	// 确认时间，Unix 纳秒时间戳，未设置为 0
+	AckTime int64 `protobuf:"varint,4,opt,name=ackTime,proto3" json:"ackTime,omitempty"`
+	// 错误计数
+	ErrCount int64 `protobuf:"varint,5,opt,name=errCount,proto3" json:"errCount,omitempty"`
+	// 方法
+	Method string `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"`
+	// 来源 ID
+	FormId string `protobuf:"bytes,7,opt,name=formId,proto3" json:"formId,omitempty"`
+	// 消息数据
+	//
+	// Types that are assignable to Data:
+	//	*Message_Payload
+	//	*Message_Json
+	Data isMessage_Data `protobuf_oneof:"data"`
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_ws_websocket_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_ws_websocket_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_apps_im_ws_websocket_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Message) GetFrameType() uint32 {
+	if x != nil {
+		return x.FrameType
+	}
+	return 0
+}
+
+func (x *Message) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Message) GetAckSeq() int64 {
+	if x != nil {
+		return x.AckSeq
+	}
+	return 0
+}
+
+func (x *Message) GetAckTime() int64 {
+	if x != nil {
+		return x.AckTime
+	}
+	return 0
+}
+
+func (x *Message) GetErrCount() int64 {
+	if x != nil {
+		return x.ErrCount
+	}
+	return 0
+}
+
+func (x *Message) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Message) GetFormId() string {
+	if x != nil {
+		return x.FormId
+	}
+	return ""
+}
+
+func (m *Message) GetData() isMessage_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *Message) GetPayload() *anypb.Any {
+	if x, ok := x.GetData().(*Message_Payload); ok {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Message) GetJson() []byte {
+	if x, ok := x.GetData().(*Message_Json); ok {
+		return x.Json
+	}
+	return nil
+}
+
+type isMessage_Data interface {
+	isMessage_Data()
+}
+
+type Message_Payload struct {
+	// 结构化数据，例如聊天消息、推送消息与已读消息
+	Payload *anypb.Any `protobuf:"bytes,8,opt,name=payload,proto3,oneof"`
+}
+
+type Message_Json struct {
+	// 没有对应 protobuf 类型的数据，使用 JSON 编码
+	Json []byte `protobuf:"bytes,9,opt,name=json,proto3,oneof"`
+}
+
+func (*Message_Payload) isMessage_Data() {}
+
+func (*Message_Json) isMessage_Data() {}
+
+// GoAway 服务器关闭前发送给客户端的下线通知。
+type GoAway struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 关闭原因
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// 客户端是否应重新连接
+	Reconnect bool `protobuf:"varint,2,opt,name=reconnect,proto3" json:"reconnect,omitempty"`
+	// 建议重新连接前等待的毫秒数
+	RetryAfter int64 `protobuf:"varint,3,opt,name=retryAfter,proto3" json:"retryAfter,omitempty"`
+}
+
+func (x *GoAway) Reset() {
+	*x = GoAway{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_ws_websocket_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoAway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoAway) ProtoMessage() {}
+
+func (x *GoAway) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_ws_websocket_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoAway.ProtoReflect.Descriptor instead.
+func (*GoAway) Descriptor() ([]byte, []int) {
+	return file_apps_im_ws_websocket_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GoAway) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GoAway) GetReconnect() bool {
+	if x != nil {
+		return x.Reconnect
+	}
+	return false
+}
+
+func (x *GoAway) GetRetryAfter() int64 {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return 0
+}
+
+// Error 携带错误码的错误帧数据。
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 错误码
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// 错误信息
+	Msg string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_ws_websocket_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_ws_websocket_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_apps_im_ws_websocket_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+var File_apps_im_ws_websocket_message_proto protoreflect.FileDescriptor
+
+var file_apps_im_ws_websocket_message_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x69, 0x6d, 0x2f, 0x77, 0x73, 0x2f, 0x77, 0x65, 0x62,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x1a,
+	0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x85, 0x02, 0x0a, 0x07, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x6b, 0x53, 0x65, 0x71, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x63, 0x6b, 0x53, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61,
+	0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x72, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x72, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x49, 0x64, 0x12, 0x30, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x48, 0x00, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x5e, 0x0a, 0x06, 0x47, 0x6f, 0x41, 0x77, 0x61, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74,
+	0x65, 0x72, 0x22, 0x2d, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73,
+	0x67, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_apps_im_ws_websocket_message_proto_rawDescOnce sync.Once
+	file_apps_im_ws_websocket_message_proto_rawDescData = file_apps_im_ws_websocket_message_proto_rawDesc
+)
+
+func file_apps_im_ws_websocket_message_proto_rawDescGZIP() []byte {
+	file_apps_im_ws_websocket_message_proto_rawDescOnce.Do(func() {
+		file_apps_im_ws_websocket_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_apps_im_ws_websocket_message_proto_rawDescData)
+	})
+	return file_apps_im_ws_websocket_message_proto_rawDescData
+}
+
+var file_apps_im_ws_websocket_message_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_apps_im_ws_websocket_message_proto_goTypes = []interface{}{
+	(*Message)(nil),   // 0: websocket.Message
+	(*GoAway)(nil),    // 1: websocket.GoAway
+	(*Error)(nil),     // 2: websocket.Error
+	(*anypb.Any)(nil), // 3: google.protobuf.Any
+}
+var file_apps_im_ws_websocket_message_proto_depIdxs = []int32{
+	3, // 0: websocket.Message.payload:type_name -> google.protobuf.Any
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_apps_im_ws_websocket_message_proto_init() }
+func file_apps_im_ws_websocket_message_proto_init() {
+	if File_apps_im_ws_websocket_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_apps_im_ws_websocket_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_ws_websocket_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GoAway); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_ws_websocket_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_apps_im_ws_websocket_message_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Message_Payload)(nil),
+		(*Message_Json)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_apps_im_ws_websocket_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apps_im_ws_websocket_message_proto_goTypes,
+		DependencyIndexes: file_apps_im_ws_websocket_message_proto_depIdxs,
+		MessageInfos:      file_apps_im_ws_websocket_message_proto_msgTypes,
+	}.Build()
+	File_apps_im_ws_websocket_message_proto = out.File
+	file_apps_im_ws_websocket_message_proto_rawDesc = nil
+	file_apps_im_ws_websocket_message_proto_goTypes = nil
+	file_apps_im_ws_websocket_message_proto_depIdxs = nil
+}
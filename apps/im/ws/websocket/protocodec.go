@@ -0,0 +1,150 @@
+package websocket
+
+import (
+	"easy-chat/apps/im/ws/websocket/pb"
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"time"
+)
+
+// ProtoPayloads 在消息数据与 protobuf 结构化数据之间转换，由业务定义消息数据的 protobuf 类型。
+type ProtoPayloads interface {
+	// ToProto 将消息数据转换为 protobuf 消息，不支持的数据返回 false，按 JSON 编码。
+	ToProto(data any) (proto.Message, bool)
+	// FromProto 将 protobuf 消息转换为消息数据，不支持的消息返回 false，原样作为消息数据。
+	FromProto(m proto.Message) (any, bool)
+}
+
+type protoCodec struct {
+	payloads ProtoPayloads
+}
+
+// NewProtoCodec 创建使用 protobuf 二进制帧的编解码器。
+//
+// 消息以 pb.Message 编码，消息数据由 payloads 转换为 protobuf 消息后以 Any 类型携带，
+// 没有对应 protobuf 类型的数据使用 JSON 编码，解码后与 JSON 编解码器的结果相同。
+// 解码出的结构化数据是 payloads 转换后的业务类型，路由处理函数仍可使用 mapstructure 解码。
+//
+// 参数:
+//   - payloads: 消息数据与 protobuf 消息的转换，为 nil 时只有下线通知与错误码使用结构化数据。
+//
+// 返回:
+//   - Codec: protobuf 编解码器。
+func NewProtoCodec(payloads ProtoPayloads) Codec {
+	return &protoCodec{payloads: payloads}
+}
+
+func (c *protoCodec) Name() string { return ProtobufProtocol }
+
+func (c *protoCodec) MessageType() int { return websocket.BinaryMessage }
+
+// Marshal 编码 Message 或 *Message。
+func (c *protoCodec) Marshal(v any) ([]byte, error) {
+	var msg *Message
+	switch m := v.(type) {
+	case *Message:
+		msg = m
+	case Message:
+		msg = &m
+	default:
+		return nil, ErrCodecMessage
+	}
+
+	frame := &pb.Message{
+		FrameType: uint32(msg.FrameType),
+		Id:        msg.Id,
+		AckSeq:    int64(msg.AckSeq),
+		ErrCount:  int64(msg.ErrCount),
+		Method:    msg.Method,
+		FormId:    msg.FormId,
+	}
+	if !msg.AckTime.IsZero() {
+		frame.AckTime = msg.AckTime.UnixNano()
+	}
+	if msg.Data != nil {
+		if m, ok := c.toProto(msg.Data); ok {
+			payload, err := anypb.New(m)
+			if err != nil {
+				return nil, err
+			}
+			frame.Data = &pb.Message_Payload{Payload: payload}
+		} else {
+			data, err := json.Marshal(msg.Data)
+			if err != nil {
+				return nil, err
+			}
+			frame.Data = &pb.Message_Json{Json: data}
+		}
+	}
+	return proto.Marshal(frame)
+}
+
+// Unmarshal 解码到 *Message。
+func (c *protoCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(*Message)
+	if !ok {
+		return ErrCodecMessage
+	}
+
+	var frame pb.Message
+	if err := proto.Unmarshal(data, &frame); err != nil {
+		return err
+	}
+	*msg = Message{
+		FrameType: FrameType(frame.FrameType),
+		Id:        frame.Id,
+		AckSeq:    int(frame.AckSeq),
+		ErrCount:  int(frame.ErrCount),
+		Method:    frame.Method,
+		FormId:    frame.FormId,
+	}
+	if frame.AckTime != 0 {
+		msg.AckTime = time.Unix(0, frame.AckTime)
+	}
+
+	switch d := frame.Data.(type) {
+	case *pb.Message_Payload:
+		m, err := d.Payload.UnmarshalNew()
+		if err != nil {
+			return err
+		}
+		msg.Data = c.fromProto(m)
+	case *pb.Message_Json:
+		return json.Unmarshal(d.Json, &msg.Data)
+	}
+	return nil
+}
+
+// toProto 将消息数据转换为 protobuf 消息。
+func (c *protoCodec) toProto(data any) (proto.Message, bool) {
+	switch d := data.(type) {
+	case proto.Message:
+		return d, true
+	case *GoAway:
+		return &pb.GoAway{Reason: d.Reason, Reconnect: d.Reconnect, RetryAfter: d.RetryAfter}, true
+	case *Error:
+		return &pb.Error{Code: int32(d.Code), Msg: d.Msg}, true
+	}
+	if c.payloads == nil {
+		return nil, false
+	}
+	return c.payloads.ToProto(data)
+}
+
+// fromProto 将 protobuf 消息转换为消息数据。
+func (c *protoCodec) fromProto(m proto.Message) any {
+	switch d := m.(type) {
+	case *pb.GoAway:
+		return &GoAway{Reason: d.Reason, Reconnect: d.Reconnect, RetryAfter: d.RetryAfter}
+	case *pb.Error:
+		return &Error{Code: ErrCode(d.Code), Msg: d.Msg}
+	}
+	if c.payloads != nil {
+		if data, ok := c.payloads.FromProto(m); ok {
+			return data
+		}
+	}
+	return m
+}
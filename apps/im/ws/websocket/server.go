@@ -3,16 +3,20 @@ package websocket
 import (
 	"context"
 	"easy-chat/apps/im/ws/websocket/auth"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/threading"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// ErrKickedByOtherDevice 同平台的其他设备登入时，发送给被踢下线设备的错误信息。
+var ErrKickedByOtherDevice = errors.New("账号已在同平台的其他设备登入")
+
 // Server 表示 WebSocket 服务器的实现。
 //
 // 字段:
@@ -30,14 +34,22 @@ import (
 //     日志记录器，用于记录服务器的日志信息，包括错误、信息和调试日志。
 //   - connToUser: map[*Conn]string
 //     连接到用户映射表，将每个 WebSocket 连接映射到其对应的用户 ID。
-//   - userToConn: map[string]*Conn
-//     用户到连接映射表，将每个用户 ID 映射到其当前的 WebSocket 连接。
+//   - userToConn: map[string]map[string]*Conn
+//     用户到连接映射表，将每个用户 ID 映射到其各个设备（以设备 ID 为键）的 WebSocket 连接。
 //   - TaskRunner: *threading.TaskRunner
 //     任务运行器，用于管理和执行异步任务。
 //   - RWMutex: sync.RWMutex
 //     读写互斥锁，用于保护连接和用户映射表的并发读写操作。
+//   - pushSeq: uint64
+//     推送消息 ID 生成序号，用于为未设置 ID 的推送消息生成唯一 ID。
 //   - authentication: Authentication
 //     鉴权接口，负责处理 WebSocket 连接的鉴权逻辑。
+//   - httpServer: *http.Server
+//     服务器独立使用的 HTTP 服务，可以被优雅关闭。
+//   - closing: atomic.Bool
+//     服务器是否正在关闭，关闭期间拒绝新的连接。
+//   - handlers / writers / callbacks: sync.WaitGroup
+//     正在执行的路由处理函数、连接写协程与未送达回调，关闭时等待它们结束。
 type Server struct {
 	routes   map[string]HandlerFunc
 	addr     string
@@ -47,11 +59,19 @@ type Server struct {
 	logx.Logger
 
 	connToUser map[*Conn]string
-	userToConn map[string]*Conn
+	userToConn map[string]map[string]*Conn
 	*threading.TaskRunner
 	sync.RWMutex
 
+	pushSeq uint64 // 推送消息 ID 生成序号
+
 	authentication auth.Authentication
+
+	httpServer *http.Server
+	closing    atomic.Bool
+	handlers   sync.WaitGroup // 正在执行的路由处理函数
+	writers    sync.WaitGroup // 连接写协程
+	callbacks  sync.WaitGroup // 未送达回调
 }
 
 // NewServer 创建一个新的服务器实例
@@ -70,7 +90,7 @@ func NewServer(addr string, opts ...ServerOptions) *Server {
 	// 创建新的服务器配置选项
 	opt := newWebsocketServerOption(opts...)
 
-	return &Server{
+	s := &Server{
 		routes: make(map[string]HandlerFunc),
 		addr:   addr,
 		patten: opt.patten,
@@ -79,13 +99,22 @@ func NewServer(addr string, opts ...ServerOptions) *Server {
 			CheckOrigin: func(r *http.Request) bool {
 				return true
 			},
+			EnableCompression: opt.compression,
 		},
 		Logger:         logx.WithContext(context.Background()),
 		connToUser:     make(map[*Conn]string),
-		userToConn:     make(map[string]*Conn),
+		userToConn:     make(map[string]map[string]*Conn),
 		authentication: opt.Authentication,
 		TaskRunner:     threading.NewTaskRunner(opt.concurrency),
 	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.patten, s.ServerWs)
+	s.httpServer = &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+	return s
 }
 
 // SendByUserIds 向指定的用户 ID 发送消息。
@@ -111,64 +140,108 @@ func (s *Server) SendByUserIds(msg interface{}, sendIds ...string) error {
 // Send 向指定的连接发送消息。
 //
 // 该方法用于将消息发送到一个或多个 WebSocket 连接。
-// 首先将消息序列化为 JSON 格式，然后遍历连接列表，将消息通过 WebSocket 发送到每个连接中。
+// 首先使用各连接协商的编解码器序列化消息（每种编解码器只序列化一次），然后放入每个连接的发送队列，
+// 由各连接的写协程写出，慢连接不会阻塞对其他连接的发送。
 // 如果没有指定连接，则不执行任何操作。
-// 如果在发送过程中发生错误，方法将立即返回该错误；如果成功，则返回 nil。
+// 某个连接发送失败不影响其他连接，全部连接处理完后返回 *SendError，其中包含每个失败的连接及原因。
 //
 // 参数:
-//   - msg: 要发送的消息，可以是任何类型的数据。
+//   - msg: 要发送的消息，通常为 *Message。
 //   - conns: 要发送消息的连接列表。
 //
 // 返回:
-//   - error: 如果发送过程中发生错误，返回错误信息；如果成功，则返回 nil。
+//   - error: 部分连接发送失败（包括序列化失败）时返回 *SendError；全部成功则返回 nil。
 func (s *Server) Send(msg interface{}, conns ...*Conn) error {
 	// 如果没有指定连接，则不执行任何操作
 	if len(conns) == 0 {
 		return nil
 	}
 
-	// 将消息序列化为 JSON 格式
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return err
+	// 按编解码器序列化消息，并放入每个连接的发送队列
+	var (
+		sendErr *SendError
+		encoded = make(map[string][]byte, 1)
+	)
+	for _, conn := range conns {
+		err := s.enqueue(conn, msg, encoded)
+		if err != nil {
+			if sendErr == nil {
+				sendErr = &SendError{}
+			}
+			sendErr.Failed = append(sendErr.Failed, ConnError{Conn: conn, Err: err})
+		}
 	}
+	if sendErr != nil {
+		return sendErr
+	}
+	return nil
+}
 
-	// 遍历连接列表，将消息发送到每个连接中
-	for _, conn := range conns {
-		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+// enqueue 使用连接的编解码器序列化消息并放入连接的发送队列，序列化结果按编解码器缓存在 encoded 中。
+func (s *Server) enqueue(conn *Conn, msg interface{}, encoded map[string][]byte) error {
+	codec := conn.codec
+	data, ok := encoded[codec.Name()]
+	if !ok {
+		var err error
+		if data, err = codec.Marshal(msg); err != nil {
 			return err
 		}
+		encoded[codec.Name()] = data
 	}
-
-	return nil
+	return conn.enqueue(codec.MessageType(), data)
 }
 
-// GetConn 根据用户 ID 获取 WebSocket 连接。
+// GetConn 根据用户 ID 获取该用户所有在线设备的 WebSocket 连接。
 //
-// 该方法用于根据用户 ID 从服务器的用户到连接的映射中获取对应的 WebSocket 连接。
-// 如果找不到对应的连接，返回 nil。
+// 该方法用于根据用户 ID 从服务器的用户到连接的映射中获取该用户在各个设备上的 WebSocket 连接。
+// 如果用户不在线，返回 nil。
 //
 // 参数:
 //   - uid: 用户的 ID。
 //
 // 返回:
-//   - *Conn: 对应用户 ID 的 WebSocket 连接；如果未找到，则返回 nil。
-func (s *Server) GetConn(uid string) *Conn {
-	s.RWMutex.Lock()
-	defer s.RWMutex.Unlock()
-	return s.userToConn[uid]
+//   - []*Conn: 对应用户 ID 的全部设备连接；如果未找到，则返回 nil。
+func (s *Server) GetConn(uid string) []*Conn {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+
+	devices := s.userToConn[uid]
+	if len(devices) == 0 {
+		return nil
+	}
+
+	res := make([]*Conn, 0, len(devices))
+	for _, conn := range devices {
+		res = append(res, conn)
+	}
+	return res
+}
+
+// GetDeviceConn 根据用户 ID 和设备 ID 获取指定设备的 WebSocket 连接。
+//
+// 参数:
+//   - uid: 用户的 ID。
+//   - deviceId: 设备的 ID。
+//
+// 返回:
+//   - *Conn: 对应设备的连接；如果该设备不在线，则返回 nil。
+func (s *Server) GetDeviceConn(uid, deviceId string) *Conn {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+	return s.userToConn[uid][deviceId]
 }
 
 // GetConns 根据用户 ID 列表获取 WebSocket 连接列表。
 //
-// 该方法用于根据用户 ID 列表从服务器的用户到连接的映射中获取对应的 WebSocket 连接列表。
+// 该方法用于根据用户 ID 列表从服务器的用户到连接的映射中获取对应的 WebSocket 连接列表，
+// 每个用户的所有在线设备连接都会被返回，不在线的用户会被忽略。
 // 如果用户 ID 列表为空，返回一个空的连接列表。
 //
 // 参数:
 //   - uids: 用户的 ID 列表。
 //
 // 返回:
-//   - []*Conn: 对应用户 ID 的 WebSocket 连接列表；如果用户 ID 列表为空，则返回 nil。
+//   - []*Conn: 对应用户 ID 的全部设备连接列表；如果用户 ID 列表为空，则返回 nil。
 func (s *Server) GetConns(uids ...string) []*Conn {
 	// 如果没有用户 ID，返回空列表
 	if len(uids) == 0 {
@@ -178,10 +251,12 @@ func (s *Server) GetConns(uids ...string) []*Conn {
 	s.RWMutex.RLock()
 	defer s.RWMutex.RUnlock()
 
-	// 遍历用户 ID 列表，获取对应的连接
+	// 遍历用户 ID 列表，获取每个用户所有设备的连接
 	res := make([]*Conn, 0, len(uids))
 	for _, uid := range uids {
-		res = append(res, s.userToConn[uid])
+		for _, conn := range s.userToConn[uid] {
+			res = append(res, conn)
+		}
 	}
 	return res
 }
@@ -189,7 +264,8 @@ func (s *Server) GetConns(uids ...string) []*Conn {
 // GetUsers 获取与指定连接关联的用户 ID 列表。
 //
 // 该方法用于从服务器获取与连接相关的用户 ID 列表。
-// 如果没有指定连接，则返回所有用户的 ID 列表；如果指定了连接，则返回这些连接对应的用户 ID 列表。
+// 如果没有指定连接，则返回所有在线用户的 ID 列表（同一用户多个设备只返回一次）；
+// 如果指定了连接，则返回这些连接对应的用户 ID 列表。
 //
 // 参数:
 //   - conns: 要获取用户 ID 的 WebSocket 连接列表。如果为空，则返回所有用户的 ID 列表。
@@ -203,8 +279,8 @@ func (s *Server) GetUsers(conns ...*Conn) []string {
 	var res []string
 	if len(conns) == 0 {
 		// 获取全部用户 ID
-		res = make([]string, 0, len(s.connToUser))
-		for _, uid := range s.connToUser {
+		res = make([]string, 0, len(s.userToConn))
+		for uid := range s.userToConn {
 			res = append(res, uid)
 		}
 	} else {
@@ -222,27 +298,46 @@ func (s *Server) GetUsers(conns ...*Conn) []string {
 //
 // 该方法用于关闭 WebSocket 连接并从服务器的连接映射中删除该连接。
 // 如果连接已经被关闭（即用户 ID 为空），则不执行任何操作。
-// 关闭连接后，将从 `connToUser` 和 `userToConn` 映射中移除相关条目。
+// 关闭连接后，将从 `connToUser` 和 `userToConn` 映射中移除该设备的条目，
+// 同一用户其他设备上的连接不受影响，最后执行连接断开回调。
 //
 // 参数:
 //   - conn: 要关闭的 WebSocket 连接。
 func (s *Server) Close(conn *Conn) {
 	s.RWMutex.Lock()
-	defer s.RWMutex.Unlock()
 
 	// 获取用户 ID
 	uid := s.connToUser[conn]
 	// 如果用户 ID 为空，表示连接已经被关闭，不执行任何操作
 	if uid == "" {
+		s.RWMutex.Unlock()
 		return
 	}
 
 	// 从映射中删除连接
-	delete(s.connToUser, conn)
-	delete(s.userToConn, uid)
+	s.removeConn(uid, conn)
+	s.RWMutex.Unlock()
 
 	// 关闭 WebSocket 连接
 	conn.Close()
+
+	// 连接断开回调
+	s.runHooks(s.opt.closeHooks, conn)
+}
+
+// removeConn 从连接映射中移除指定用户的设备连接，调用方需持有写锁。
+//
+// 只有当设备 ID 对应的仍是该连接时才会删除设备条目，避免误删同一设备重连后建立的新连接。
+func (s *Server) removeConn(uid string, conn *Conn) {
+	delete(s.connToUser, conn)
+
+	devices := s.userToConn[uid]
+	if devices[conn.DeviceId] == conn {
+		delete(devices, conn.DeviceId)
+	}
+	if len(devices) == 0 {
+		delete(s.userToConn, uid)
+	}
 }
 
 // AddRoutes 注册 WebSocket 路由处理函数。
@@ -273,6 +368,12 @@ func (s *Server) ServerWs(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	// 服务器正在关闭，拒绝新的连接，客户端应连接其他节点
+	if s.closing.Load() {
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
 	// 创建 WebSocket 连接对象
 	conn := NewConn(s, w, r)
 	if conn == nil {
@@ -289,54 +390,145 @@ func (s *Server) ServerWs(w http.ResponseWriter, r *http.Request) {
 	// 记录连接
 	s.addConn(conn, r)
 
+	// 连接建立回调
+	s.runHooks(s.opt.connectHooks, conn)
+
 	// 启动处理连接的任务，根据请求类型处理请求
 	go s.handlerConn(conn)
 }
 
 // Start 启动服务器
 //
-// 该方法用于启动HTTP服务器并开始监听指定的地址。它将处理所有传入的请求，并调用
-// `ServerWs` 方法处理WebSocket连接。启动后，服务器将会持续运行，直到出现错误或
-// 被手动停止。
+// 该方法使用服务器独立的 HTTP 服务监听指定的地址，并调用 `ServerWs` 方法处理WebSocket连接。
+// 启动后，服务器将会持续运行，直到出现错误或被 Stop/Shutdown 关闭。
 func (s *Server) Start() {
-	// 设置路由处理函数
-	http.HandleFunc(s.patten, s.ServerWs)
-	s.Info(http.ListenAndServe(s.addr, nil))
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.Error(err)
+	}
 }
 
 // Stop 停止服务器
 //
-// 该方法用于停止正在运行的服务器。它会打印一条停止服务的消息。请注意，该方法
-// 只是打印了停止服务的消息，实际停止服务的操作可能需要额外的实现。
+// 该方法在配置的关闭超时时间内优雅关闭服务器，详见 Shutdown。
 func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opt.shutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(ctx); err != nil {
+		s.Errorf("shutdown err: %v", err)
+	}
 	s.Infof("stop service")
 }
 
-// addConn 存储 WebSocket 连接并与用户 ID 关联。
+// addConn 存储 WebSocket 连接并与用户 ID、设备 ID 关联。
 //
-// 该方法用于将新的 WebSocket 连接添加到服务器中，并将其与用户 ID 进行关联。
-// 如果用户 ID 对应的连接已存在，则关闭之前的连接。
-// 将新的连接与用户 ID 关联后，更新服务器的连接映射。
+// 该方法用于将新的 WebSocket 连接添加到服务器中，并将其与用户 ID 和设备 ID 进行关联。
+// 同一用户可以在多个设备上同时在线，每个设备以设备 ID 区分：
+//   - 如果同一设备 ID 已存在连接（设备重连），则关闭之前的连接。
+//   - 如果开启了同平台互踢策略，则关闭该用户在相同平台上其他设备的连接。
 //
 // 参数:
 //   - conn: 要添加的 WebSocket 连接。
-//   - req: HTTP 请求，用于获取用户 ID。
+//   - req: HTTP 请求，用于获取用户 ID 和设备信息。
 func (s *Server) addConn(conn *Conn, req *http.Request) {
-	// 获取用户 ID
+	// 获取用户 ID 与设备信息
 	uid := s.authentication.UserId(req)
+	conn.Uid = uid
+	conn.DeviceId, conn.Platform = s.deviceInfo(req)
 
-	s.RWMutex.Lock()
-	defer s.RWMutex.Unlock()
+	// 被同平台互踢下线的连接
+	var kicked []*Conn
 
-	// 验证用户是否之前登入过
-	if c := s.userToConn[uid]; c != nil {
-		// 关闭之前的连接
+	s.RWMutex.Lock()
+	for deviceId, c := range s.userToConn[uid] {
+		switch {
+		case deviceId == conn.DeviceId:
+			// 同一设备重复登入，关闭之前的连接
+		case s.opt.kickSamePlatform && conn.Platform != "" && c.Platform == conn.Platform:
+			// 同平台互踢，通知并关闭该平台上的其他设备
+			s.kick(c)
+			kicked = append(kicked, c)
+		default:
+			continue
+		}
+		s.removeConn(uid, c)
 		c.Close()
 	}
 
-	// 将新连接与用户 ID 进行关联存储
+	// 将新连接与用户 ID、设备 ID 进行关联存储
+	devices, ok := s.userToConn[uid]
+	if !ok {
+		devices = make(map[string]*Conn)
+		s.userToConn[uid] = devices
+	}
 	s.connToUser[conn] = uid
-	s.userToConn[uid] = conn
+	devices[conn.DeviceId] = conn
+	s.RWMutex.Unlock()
+
+	// 被踢下线的设备执行连接断开回调
+	for _, c := range kicked {
+		s.runHooks(s.opt.closeHooks, c)
+	}
+}
+
+// deviceInfo 从握手请求中获取设备 ID 与平台。
+//
+// 设备 ID 优先由鉴权实现提供（例如 JWT 中的设备声明），其次读取握手参数 `deviceId`
+// 或请求头 `X-Device-Id`；如果都没有提供，则使用 DefaultDeviceId，保证设备重连后 ID 不变，
+// 能够继续接收按设备保存的未送达消息，同一用户未提供设备 ID 的连接会互相替换。
+// 平台读取握手参数 `platform` 或请求头 `X-Platform`，用于同平台互踢策略。
+//
+// 参数:
+//   - req: HTTP 请求。
+//
+// 返回:
+//   - deviceId: 设备 ID。
+//   - platform: 平台标识，可能为空。
+func (s *Server) deviceInfo(req *http.Request) (deviceId, platform string) {
+	if da, ok := s.authentication.(auth.DeviceAuthentication); ok {
+		deviceId = da.DeviceId(req)
+	}
+
+	query := req.URL.Query()
+	if deviceId == "" {
+		deviceId = query.Get("deviceId")
+	}
+	if deviceId == "" {
+		deviceId = req.Header.Get("X-Device-Id")
+	}
+	if deviceId == "" {
+		deviceId = DefaultDeviceId
+	}
+
+	platform = query.Get("platform")
+	if platform == "" {
+		platform = req.Header.Get("X-Platform")
+	}
+	return
+}
+
+// runHandler 执行路由处理函数，优雅关闭时等待正在执行的处理函数完成，处理函数 panic 时同样会被计数释放。
+func (s *Server) runHandler(handler HandlerFunc, conn *Conn, msg *Message) {
+	s.handlers.Add(1)
+	defer s.handlers.Done()
+
+	handler(s, conn, msg)
+}
+
+// runHooks 依次执行连接生命周期回调，回调中的 panic 不会影响连接处理。
+func (s *Server) runHooks(hooks []ConnHook, conn *Conn) {
+	for _, hook := range hooks {
+		threading.RunSafe(func() {
+			hook(s, conn)
+		})
+	}
+}
+
+// kick 通知连接其已被同平台的其他设备挤下线。
+func (s *Server) kick(conn *Conn) {
+	if err := s.Send(NewErrMessage(ErrKickedByOtherDevice), conn); err != nil {
+		s.Errorf("kick device send err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+	}
 }
 
 // handlerConn 根据连接对象进行任务处理。
@@ -347,10 +539,6 @@ func (s *Server) addConn(conn *Conn, req *http.Request) {
 // 参数:
 //   - conn: WebSocket 连接对象，用于接收和发送消息。
 func (s *Server) handlerConn(conn *Conn) {
-	// 获取连接对应的用户 ID 并设置到连接对象
-	uids := s.GetUsers(conn)
-	conn.Uid = uids[0]
-
 	// 启动处理任务的 goroutine
 	go s.handleWrite(conn)
 
@@ -363,6 +551,14 @@ func (s *Server) handlerConn(conn *Conn) {
 	for {
 		// 读取消息
 		_, msg, err := conn.ReadMessage()
+		if errors.Is(err, ErrMessageTooLarge) {
+			// 丢弃过大的消息，连接保持可用
+			s.Errorf("websocket read error: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+			if err := s.Send(NewErrCodeMessage(ErrCodeMessageTooLarge, err), conn); err != nil {
+				s.Errorf("error message send error: %v", err)
+			}
+			continue
+		}
 		if err != nil {
 			s.Errorf("websocket read error: %v", err)
 			s.Close(conn)
@@ -371,12 +567,17 @@ func (s *Server) handlerConn(conn *Conn) {
 
 		// 解析消息
 		var message Message
-		if err = json.Unmarshal(msg, &message); err != nil {
+		if err = conn.codec.Unmarshal(msg, &message); err != nil {
 			s.Errorf("websocket unmarshal error: %v", err)
 			s.Close(conn)
 			return
 		}
 
+		// 客户端对推送消息的送达确认
+		if message.FrameType == FrameAck && s.ackPush(conn, message.Id) {
+			continue
+		}
+
 		// 判断是否需要 ACK 确认
 		if s.isAck(&message) {
 			// 进行 ACK 确认
@@ -437,7 +638,7 @@ func (s *Server) handleWrite(conn *Conn) {
 			case FrameData:
 				// 处理 Data 消息，根据消息 Method 执行对应的处理器
 				if handler, ok := s.routes[message.Method]; ok {
-					handler(s, conn, message)
+					s.runHandler(handler, conn, message)
 				}
 			}
 
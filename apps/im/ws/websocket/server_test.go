@@ -0,0 +1,380 @@
+package websocket
+
+import (
+	"compress/flate"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"easy-chat/apps/im/ws/websocket/auth"
+	"github.com/gorilla/websocket"
+)
+
+// dialTestServer 以指定的用户、设备与平台连接测试服务器
+func dialTestServer(t *testing.T, srv *httptest.Server, uid, deviceId, platform string) *websocket.Conn {
+	t.Helper()
+
+	query := url.Values{}
+	query.Set("userId", uid)
+	query.Set("deviceId", deviceId)
+	query.Set("platform", platform)
+	u := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws?" + query.Encode()
+
+	conn, _, err := websocket.DefaultDialer.Dial(u, nil)
+	if err != nil {
+		t.Fatalf("dial err: %v", err)
+	}
+	return conn
+}
+
+// waitConns 等待服务器中指定用户的在线设备数达到预期
+func waitConns(s *Server, uid string, want int) int {
+	var got int
+	for i := 0; i < 50; i++ {
+		if got = len(s.GetConn(uid)); got == want {
+			return got
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return got
+}
+
+func TestServer_MultiDevice(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ServerOptions
+		devices [][2]string // 设备ID, 平台
+		want    int
+	}{
+		{"不同平台同时在线", nil, [][2]string{{"d1", "ios"}, {"d2", "pc"}, {"d3", "web"}}, 3},
+		{"同一设备重连", nil, [][2]string{{"d1", "ios"}, {"d1", "ios"}}, 1},
+		{"默认同平台共存", nil, [][2]string{{"d1", "ios"}, {"d2", "ios"}}, 2},
+		{"同平台互踢", []ServerOptions{WithServerKickSamePlatform()}, [][2]string{{"d1", "ios"}, {"d2", "ios"}, {"d3", "pc"}}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer("", tt.opts...)
+			srv := httptest.NewServer(http.HandlerFunc(s.ServerWs))
+			defer srv.Close()
+
+			uid := "[10086]"
+			for _, device := range tt.devices {
+				c := dialTestServer(t, srv, "10086", device[0], device[1])
+				defer c.Close()
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			if got := waitConns(s, uid, tt.want); got != tt.want {
+				t.Errorf("GetConn() got %v conns, want %v", got, tt.want)
+			}
+			if got := len(s.GetConns(uid, "offline")); got != tt.want {
+				t.Errorf("GetConns() got %v conns, want %v", got, tt.want)
+			}
+			if got := s.GetUsers(); len(got) != 1 {
+				t.Errorf("GetUsers() got %v, want one user", got)
+			}
+		})
+	}
+}
+
+func TestServer_PushAck(t *testing.T) {
+	tests := []struct {
+		name            string
+		ack             bool // 客户端是否确认送达
+		wantReceived    int  // 客户端收到的推送次数（含重传）
+		wantUndelivered bool
+	}{
+		{"客户端确认送达", true, 1, false},
+		{"未确认重传后放弃", false, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undelivered := make(chan *Message, 1)
+			s := NewServer("",
+				WithServerSendErrCount(2),
+				WithServerPushAckTimeout(20*time.Millisecond),
+				WithServerPushAck(func(srv *Server, conn *Conn, msg *Message) {
+					undelivered <- msg
+				}),
+			)
+			srv := httptest.NewServer(http.HandlerFunc(s.ServerWs))
+			defer srv.Close()
+
+			c := dialTestServer(t, srv, "10086", "d1", "ios")
+			defer c.Close()
+			waitConns(s, "[10086]", 1)
+
+			if err := s.Push(&Message{FrameType: FrameData, Id: "m1", Data: "hello"}, s.GetConn("[10086]")...); err != nil {
+				t.Fatalf("Push() err = %v", err)
+			}
+
+			var received int
+			for {
+				c.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+				var msg Message
+				if err := c.ReadJSON(&msg); err != nil {
+					break
+				}
+				received++
+				if tt.ack {
+					c.WriteJSON(&Message{FrameType: FrameAck, Id: msg.Id})
+				}
+			}
+
+			if received != tt.wantReceived {
+				t.Errorf("received %v pushes, want %v", received, tt.wantReceived)
+			}
+			select {
+			case msg := <-undelivered:
+				if !tt.wantUndelivered {
+					t.Errorf("unexpected undelivered message: %v", msg.Id)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.wantUndelivered {
+					t.Errorf("undelivered callback not called")
+				}
+			}
+		})
+	}
+}
+
+// newQueueTestConn 创建一个没有写协程的连接，用于检查发送队列的内容
+func newQueueTestConn(s *Server, uid string) *Conn {
+	return &Conn{
+		Uid:      uid,
+		s:        s,
+		pushes:   make(map[string]*pendingPush),
+		outbound: make(chan outboundFrame, s.opt.writeQueueSize),
+		codec:    JSONCodec,
+		done:     make(chan struct{}),
+	}
+}
+
+func TestServer_SendWriteQueue(t *testing.T) {
+	t.Run("队列已满丢弃最早的消息", func(t *testing.T) {
+		s := NewServer("", WithServerWriteQueue(2, OverflowDropOldest))
+		conn := newQueueTestConn(s, "u1")
+		for _, data := range []string{"m1", "m2", "m3"} {
+			if err := s.Send(data, conn); err != nil {
+				t.Fatalf("Send(%v) err = %v", data, err)
+			}
+		}
+
+		var got []string
+		for len(conn.outbound) > 0 {
+			got = append(got, string((<-conn.outbound).data))
+		}
+		if want := []string{`"m2"`, `"m3"`}; strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("queued %v, want %v", got, want)
+		}
+	})
+
+	t.Run("队列已满断开慢连接且不影响其他连接", func(t *testing.T) {
+		s := NewServer("", WithServerWriteQueue(1, OverflowDisconnect))
+		slow := newQueueTestConn(s, "slow")
+		fast := newQueueTestConn(s, "fast")
+		closed := newQueueTestConn(s, "closed")
+		closed.Close()
+
+		_ = s.Send("m1", slow)
+		err := s.Send("m2", slow, closed, fast)
+
+		var sendErr *SendError
+		if !errors.As(err, &sendErr) || len(sendErr.Failed) != 2 {
+			t.Fatalf("Send() err = %v, want 2 failed conns", err)
+		}
+		if sendErr.Failed[0].Conn != slow || !errors.Is(sendErr.Failed[0].Err, ErrSlowConsumer) {
+			t.Errorf("failed[0] = %v, want slow consumer", sendErr.Failed[0])
+		}
+		if sendErr.Failed[1].Conn != closed || !errors.Is(sendErr.Failed[1].Err, ErrConnClosed) {
+			t.Errorf("failed[1] = %v, want conn closed", sendErr.Failed[1])
+		}
+		if len(fast.outbound) != 1 {
+			t.Errorf("fast conn queued %v frames, want 1", len(fast.outbound))
+		}
+
+		// 慢连接被异步关闭
+		select {
+		case <-slow.done:
+		case <-time.After(time.Second):
+			t.Errorf("slow consumer not disconnected")
+		}
+	})
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	undelivered := make(chan *Message, 1)
+	s := NewServer("",
+		WithServerPushAckTimeout(time.Second),
+		WithServerPushAck(func(srv *Server, conn *Conn, msg *Message) {
+			undelivered <- msg
+		}),
+		WithServerShutdown(time.Second, 500*time.Millisecond, 100*time.Millisecond),
+	)
+	srv := httptest.NewServer(http.HandlerFunc(s.ServerWs))
+	defer srv.Close()
+
+	c := dialTestServer(t, srv, "10086", "d1", "ios")
+	defer c.Close()
+	waitConns(s, "[10086]", 1)
+
+	if err := s.Push(&Message{FrameType: FrameData, Id: "m1", Data: "hello"}, s.GetConn("[10086]")...); err != nil {
+		t.Fatalf("Push() err = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Shutdown(context.Background())
+	}()
+
+	// 客户端先收到推送消息，再收到下线通知，确认推送后服务器关闭连接
+	var goAway bool
+	for {
+		c.SetReadDeadline(time.Now().Add(time.Second))
+		var msg Message
+		if err := c.ReadJSON(&msg); err != nil {
+			break
+		}
+		switch msg.FrameType {
+		case FrameData:
+			c.WriteJSON(&Message{FrameType: FrameAck, Id: msg.Id})
+		case FrameGoAway:
+			goAway = true
+		}
+	}
+	if !goAway {
+		t.Errorf("go away frame not received")
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Shutdown() err = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Shutdown() not returned")
+	}
+	select {
+	case msg := <-undelivered:
+		t.Errorf("unexpected undelivered message: %v", msg.Id)
+	default:
+	}
+
+	// 关闭后拒绝新的连接
+	if _, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil); err == nil {
+		t.Errorf("dial after shutdown should fail")
+	}
+}
+
+// protocolAuth 记录鉴权时请求中剩余的子协议
+type protocolAuth struct {
+	auth.WebSocketAuth
+	protocol chan string
+}
+
+func (a *protocolAuth) Authenticate(w http.ResponseWriter, r *http.Request) bool {
+	a.protocol <- r.Header.Get("Sec-WebSocket-Protocol")
+	return true
+}
+
+func TestServer_ProtoCodec(t *testing.T) {
+	a := &protocolAuth{protocol: make(chan string, 1)}
+	s := NewServer("",
+		WithWebsocketAuthentication(a),
+		WithServerCodecs(NewProtoCodec(nil)),
+	)
+	s.AddRoutes([]Route{{
+		Method: "echo",
+		Handler: func(srv *Server, conn *Conn, msg *Message) {
+			srv.Send(NewMessage(conn.Uid, msg.Data), conn)
+		},
+	}})
+	srv := httptest.NewServer(http.HandlerFunc(s.ServerWs))
+	defer srv.Close()
+
+	// 子协议中同时携带鉴权令牌与编解码器名称
+	header := http.Header{}
+	header.Set("Sec-WebSocket-Protocol", "token")
+	c := NewClient(strings.TrimPrefix(srv.URL, "http://"), WithClientHeader(header), WithClientCodec(NewProtoCodec(nil)))
+	defer c.Close()
+
+	if got := <-a.protocol; got != "token" {
+		t.Errorf("auth protocol = %q, want token", got)
+	}
+	if c.Subprotocol() != ProtobufProtocol || c.codec.Name() != ProtobufProtocol {
+		t.Fatalf("negotiated %q, want %q", c.Subprotocol(), ProtobufProtocol)
+	}
+
+	if err := c.Send(Message{FrameType: FrameData, Method: "echo", Data: map[string]any{"content": "hello"}}); err != nil {
+		t.Fatalf("Send() err = %v", err)
+	}
+	c.SetReadDeadline(time.Now().Add(time.Second))
+	var msg Message
+	if err := c.Read(&msg); err != nil {
+		t.Fatalf("Read() err = %v", err)
+	}
+	if data, ok := msg.Data.(map[string]any); !ok || data["content"] != "hello" {
+		t.Errorf("echo data = %#v", msg.Data)
+	}
+
+	// 下线通知使用结构化数据
+	waitConns(s, s.GetUsers()[0], 1)
+	s.Send(NewGoAwayMessage("restart", time.Second), s.GetConn(s.GetUsers()[0])...)
+	if err := c.Read(&msg); err != nil {
+		t.Fatalf("Read() err = %v", err)
+	}
+	if g, ok := msg.Data.(*GoAway); msg.FrameType != FrameGoAway || !ok || g.RetryAfter != 1000 {
+		t.Errorf("go away = %v %#v", msg.FrameType, msg.Data)
+	}
+}
+
+func TestServer_MessageLimits(t *testing.T) {
+	s := NewServer("",
+		WithServerCompression(flate.BestSpeed, 16),
+		WithServerMaxMessageSize(512),
+	)
+	s.AddRoutes([]Route{{
+		Method: "echo",
+		Handler: func(srv *Server, conn *Conn, msg *Message) {
+			srv.Send(NewMessage(conn.Uid, msg.Data), conn)
+		},
+	}})
+	srv := httptest.NewServer(http.HandlerFunc(s.ServerWs))
+	defer srv.Close()
+
+	dialer := websocket.Dialer{EnableCompression: true}
+	c, resp, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("dial err: %v", err)
+	}
+	defer c.Close()
+	if ext := resp.Header.Get("Sec-WebSocket-Extensions"); !strings.Contains(ext, "permessage-deflate") {
+		t.Errorf("compression not negotiated: %q", ext)
+	}
+
+	// 过大的消息被拒绝，连接保持可用
+	c.WriteJSON(&Message{FrameType: FrameData, Method: "echo", Data: strings.Repeat("x", 1024)})
+	c.SetReadDeadline(time.Now().Add(time.Second))
+	var msg Message
+	if err := c.ReadJSON(&msg); err != nil {
+		t.Fatalf("read err: %v", err)
+	}
+	if data, ok := msg.Data.(map[string]any); msg.FrameType != FrameErr || !ok || data["code"] != float64(ErrCodeMessageTooLarge) {
+		t.Errorf("oversize reply = %v %#v", msg.FrameType, msg.Data)
+	}
+
+	c.WriteJSON(&Message{FrameType: FrameData, Method: "echo", Data: "hello, compressed world"})
+	if err := c.ReadJSON(&msg); err != nil {
+		t.Fatalf("read err: %v", err)
+	}
+	if msg.FrameType != FrameData || msg.Data != "hello, compressed world" {
+		t.Errorf("echo = %v %#v", msg.FrameType, msg.Data)
+	}
+}
@@ -0,0 +1,129 @@
+package websocket
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// drainInterval 关闭前检查连接是否已排空的间隔。
+const drainInterval = 20 * time.Millisecond
+
+// Shutdown 优雅关闭服务器。
+//
+// 关闭过程依次为：
+//   - 停止接受新的连接；
+//   - 向所有连接发送下线通知（FrameGoAway），建议客户端在随机的等待时间后重新连接；
+//   - 在 drainTimeout 内等待客户端确认已推送的消息、已读取的消息处理完成、发送队列写出；
+//   - 关闭所有连接，仍未确认的推送消息按未送达处理，由设备重连后补发，不会丢失；
+//   - 等待正在执行的路由处理函数、群消息推送任务、连接写协程与未送达回调结束。
+//
+// 参数:
+//   - ctx: 上下文对象，超时或取消时不再等待，直接返回。
+//
+// 返回:
+//   - error: 关闭 HTTP 服务出错，或在 ctx 结束前未能完成关闭时返回错误。
+func (s *Server) Shutdown(ctx context.Context) error {
+	if !s.closing.CompareAndSwap(false, true) {
+		return nil
+	}
+	s.Info("websocket server shutting down")
+
+	// 停止接受新的连接，WebSocket 连接已被接管，不受 HTTP 服务关闭的影响
+	err := s.httpServer.Shutdown(ctx)
+
+	// 通知客户端重新连接
+	conns := s.conns()
+	for _, conn := range conns {
+		if err := s.Send(NewGoAwayMessage("server shutting down", s.reconnectDelay()), conn); err != nil {
+			s.Errorf("go away send err: %v, uid: %v, device: %v", err, conn.Uid, conn.DeviceId)
+		}
+	}
+
+	// 等待连接排空后关闭
+	drainCtx, cancel := context.WithTimeout(ctx, s.opt.drainTimeout)
+	s.drain(drainCtx, conns)
+	cancel()
+	for _, conn := range conns {
+		s.Close(conn)
+	}
+
+	// 等待进行中的任务结束
+	done := make(chan struct{})
+	go func() {
+		s.handlers.Wait()
+		s.TaskRunner.Wait()
+		s.writers.Wait()
+		s.callbacks.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return err
+}
+
+// conns 返回服务器上的全部连接。
+func (s *Server) conns() []*Conn {
+	s.RWMutex.RLock()
+	defer s.RWMutex.RUnlock()
+
+	res := make([]*Conn, 0, len(s.connToUser))
+	for conn := range s.connToUser {
+		res = append(res, conn)
+	}
+	return res
+}
+
+// drain 等待连接排空：推送消息均已确认、已读取的消息均已处理、发送队列均已写出，或者 ctx 结束。
+func (s *Server) drain(ctx context.Context, conns []*Conn) {
+	ticker := time.NewTicker(drainInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.drained(conns) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drained 判断连接是否都已排空。
+func (s *Server) drained(conns []*Conn) bool {
+	for _, conn := range conns {
+		select {
+		case <-conn.done:
+			// 已断开的连接无需等待
+			continue
+		default:
+		}
+		if len(conn.outbound) > 0 || len(conn.message) > 0 || pending(&conn.pushMu, func() int { return len(conn.pushes) }) > 0 ||
+			pending(&conn.messageMu, func() int { return len(conn.readMessage) }) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pending 在锁的保护下读取队列长度。
+func pending(mu *sync.Mutex, size func() int) int {
+	mu.Lock()
+	defer mu.Unlock()
+	return size()
+}
+
+// reconnectDelay 返回建议客户端重连前等待的时间：reconnectDelay 到其两倍之间的随机值。
+func (s *Server) reconnectDelay() time.Duration {
+	d := s.opt.reconnectDelay
+	if d <= 0 {
+		return 0
+	}
+	return d + time.Duration(rand.Int63n(int64(d)))
+}
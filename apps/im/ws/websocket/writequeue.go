@@ -0,0 +1,155 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"github.com/zeromicro/go-zero/core/threading"
+	"strings"
+	"time"
+)
+
+// OverflowPolicy 连接发送队列已满时的处理策略。
+type OverflowPolicy int
+
+const (
+	// OverflowDisconnect 断开消费过慢的连接，等待确认的推送消息按未送达处理，设备重连后补发。
+	OverflowDisconnect OverflowPolicy = iota
+	// OverflowDropOldest 丢弃队列中最早的消息，为新消息腾出位置。
+	OverflowDropOldest
+)
+
+var (
+	// ErrConnClosed 连接已关闭，消息未进入发送队列。
+	ErrConnClosed = errors.New("websocket: connection closed")
+	// ErrSlowConsumer 连接的发送队列已满，连接因消费过慢被断开。
+	ErrSlowConsumer = errors.New("websocket: slow consumer disconnected")
+)
+
+// outboundFrame 发送队列中等待写入的数据帧。
+type outboundFrame struct {
+	messageType int
+	data        []byte
+}
+
+// ConnError 单个连接的发送错误。
+type ConnError struct {
+	Conn *Conn
+	Err  error
+}
+
+// SendError 发送给多个连接时，发送失败的连接及原因，其余连接的发送不受影响。
+type SendError struct {
+	Failed []ConnError
+}
+
+func (e *SendError) Error() string {
+	msgs := make([]string, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		msgs = append(msgs, fmt.Sprintf("uid: %v, device: %v, err: %v", f.Conn.Uid, f.Conn.DeviceId, f.Err))
+	}
+	return fmt.Sprintf("websocket: send failed on %d conns: %s", len(e.Failed), strings.Join(msgs, "; "))
+}
+
+// Unwrap 返回各连接的发送错误，可以使用 errors.Is 判断是否包含某类错误。
+func (e *SendError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// enqueue 将数据帧放入连接的发送队列，由连接的写协程写出，不会阻塞调用方。
+//
+// 队列已满时按服务器配置的 OverflowPolicy 处理：丢弃最早的消息，或者断开连接并返回 ErrSlowConsumer。
+//
+// 参数:
+//   - messageType: WebSocket 消息类型。
+//   - data: 消息内容。
+//
+// 返回:
+//   - error: 连接已关闭或因消费过慢被断开时返回错误。
+func (c *Conn) enqueue(messageType int, data []byte) error {
+	select {
+	case <-c.done:
+		return ErrConnClosed
+	default:
+	}
+
+	f := outboundFrame{messageType: messageType, data: data}
+	select {
+	case c.outbound <- f:
+		return nil
+	default:
+	}
+
+	switch c.s.opt.overflowPolicy {
+	case OverflowDropOldest:
+		c.queueMu.Lock()
+		defer c.queueMu.Unlock()
+
+		var dropped int
+		for {
+			select {
+			case c.outbound <- f:
+				c.s.Infof("write queue full, dropped %v oldest frames, uid: %v, device: %v", dropped, c.Uid, c.DeviceId)
+				return nil
+			default:
+			}
+			select {
+			case <-c.outbound:
+				dropped++
+			default:
+			}
+		}
+	default:
+		c.s.Errorf("write queue full, disconnect slow consumer, uid: %v, device: %v", c.Uid, c.DeviceId)
+		threading.GoSafe(func() {
+			c.s.Close(c)
+			c.Close()
+		})
+		return ErrSlowConsumer
+	}
+}
+
+// writeLoop 连接的写协程，依次写出发送队列中的数据帧。
+//
+// 写入失败（包括超过写超时）时关闭连接；连接关闭时先在一个写超时内尽量写出队列中剩余的数据帧
+// （例如被踢下线的通知），再关闭底层连接。
+func (c *Conn) writeLoop() {
+	defer c.s.writers.Done()
+	defer c.wsConn.Close()
+
+	for {
+		select {
+		case f := <-c.outbound:
+			if err := c.writeMessage(f.messageType, f.data); err != nil {
+				c.s.Errorf("websocket write error: %v, uid: %v, device: %v", err, c.Uid, c.DeviceId)
+				c.s.Close(c)
+				c.Close()
+				return
+			}
+		case <-c.done:
+			c.flush()
+			return
+		}
+	}
+}
+
+// flush 在一个写超时内写出发送队列中剩余的数据帧。
+func (c *Conn) flush() {
+	if c.s.opt.writeTimeout > 0 {
+		c.wsConn.SetWriteDeadline(time.Now().Add(c.s.opt.writeTimeout))
+	}
+	for {
+		select {
+		case f := <-c.outbound:
+			c.setWriteCompression(len(f.data))
+			if err := c.wsConn.WriteMessage(f.messageType, f.data); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package ws
+
+import (
+	"easy-chat/apps/im/ws/ws/wspb"
+	"easy-chat/pkg/constants"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoPayloads 在消息数据与 wspb 中的 protobuf 类型之间转换，用于 websocket 的 protobuf 编解码器。
+//
+// 支持 Chat、Push 与 MarkRead，其余数据由编解码器使用 JSON 编码。
+// 解码得到的是 *Chat、*Push 与 *MarkRead，路由处理函数仍使用 mapstructure 解码。
+type ProtoPayloads struct{}
+
+// ToProto 将消息数据转换为 protobuf 消息。
+//
+// 参数:
+//   - data: 消息数据。
+//
+// 返回值:
+//   - proto.Message: 转换后的 protobuf 消息。
+//   - bool: 是否支持该数据类型。
+func (ProtoPayloads) ToProto(data any) (proto.Message, bool) {
+	switch d := data.(type) {
+	case *Chat:
+		return chatToProto(d), true
+	case Chat:
+		return chatToProto(&d), true
+	case *Push:
+		return pushToProto(d), true
+	case Push:
+		return pushToProto(&d), true
+	case *MarkRead:
+		return markReadToProto(d), true
+	case MarkRead:
+		return markReadToProto(&d), true
+	}
+	return nil, false
+}
+
+// FromProto 将 protobuf 消息转换为消息数据。
+//
+// 参数:
+//   - m: protobuf 消息。
+//
+// 返回值:
+//   - any: 转换后的消息数据。
+//   - bool: 是否支持该 protobuf 类型。
+func (ProtoPayloads) FromProto(m proto.Message) (any, bool) {
+	switch d := m.(type) {
+	case *wspb.Chat:
+		return chatFromProto(d), true
+	case *wspb.Push:
+		return pushFromProto(d), true
+	case *wspb.MarkRead:
+		return markReadFromProto(d), true
+	}
+	return nil, false
+}
+
+func chatToProto(c *Chat) *wspb.Chat {
+	return &wspb.Chat{
+		ConversationId: c.ConversationId,
+		Seq:            c.Seq,
+		ChatType:       int32(c.ChatType),
+		SendId:         c.SendId,
+		RecvId:         c.RecvId,
+		SendTime:       c.SendTime,
+		ContentType:    int32(c.ContentType),
+		Silent:         c.Silent,
+		ReadSeq:        c.ReadSeq,
+		Unread:         c.Unread,
+		Typing:         c.Typing,
+		Online:         c.Online,
+		LastSeen:       c.LastSeen,
+		Msg: &wspb.Msg{
+			MsgId:      c.MsgId,
+			ReadCounts: c.ReadCounts,
+			Reactions:  reactionsToProto(c.Reactions),
+			MType:      int32(c.MType),
+			Content:    c.Content,
+			Payload:    payloadToProto(c.Payload),
+			ReplyTo:    c.ReplyTo,
+			Mentions:   c.Mentions,
+			MentionAll: c.MentionAll,
+			EditedAt:   c.EditedAt,
+		},
+	}
+}
+
+func chatFromProto(c *wspb.Chat) *Chat {
+	m := c.GetMsg()
+	return &Chat{
+		ConversationId: c.ConversationId,
+		Seq:            c.Seq,
+		ChatType:       constants.ChatType(c.ChatType),
+		SendId:         c.SendId,
+		RecvId:         c.RecvId,
+		SendTime:       c.SendTime,
+		ContentType:    constants.ContentType(c.ContentType),
+		Silent:         c.Silent,
+		ReadSeq:        c.ReadSeq,
+		Unread:         c.Unread,
+		Typing:         c.Typing,
+		Online:         c.Online,
+		LastSeen:       c.LastSeen,
+		Msg: Msg{
+			MsgId:      m.GetMsgId(),
+			ReadCounts: m.GetReadCounts(),
+			Reactions:  reactionsFromProto(m.GetReactions()),
+			MType:      constants.MType(m.GetMType()),
+			Content:    m.GetContent(),
+			Payload:    payloadFromProto(m.GetPayload()),
+			ReplyTo:    m.GetReplyTo(),
+			Mentions:   m.GetMentions(),
+			MentionAll: m.GetMentionAll(),
+			EditedAt:   m.GetEditedAt(),
+		},
+	}
+}
+
+func pushToProto(p *Push) *wspb.Push {
+	return &wspb.Push{
+		ConversationId: p.ConversationId,
+		Seq:            p.Seq,
+		ChatType:       int32(p.ChatType),
+		SendId:         p.SendId,
+		RecvId:         p.RecvId,
+		RecvIds:        p.RecvIds,
+		SendTime:       p.SendTime,
+		MsgId:          p.MsgId,
+		ReadCounts:     p.ReadCounts,
+		Reactions:      reactionsToProto(p.Reactions),
+		ContentType:    int32(p.ContentType),
+		MType:          int32(p.MType),
+		Content:        p.Content,
+		Payload:        payloadToProto(p.Payload),
+		ReplyTo:        p.ReplyTo,
+		Mentions:       p.Mentions,
+		MentionAll:     p.MentionAll,
+		EditedAt:       p.EditedAt,
+		Muted:          p.Muted,
+		ReadSeq:        p.ReadSeq,
+		Unread:         p.Unread,
+		Typing:         p.Typing,
+		Online:         p.Online,
+		LastSeen:       p.LastSeen,
+	}
+}
+
+func pushFromProto(p *wspb.Push) *Push {
+	return &Push{
+		ConversationId: p.ConversationId,
+		Seq:            p.Seq,
+		ChatType:       constants.ChatType(p.ChatType),
+		SendId:         p.SendId,
+		RecvId:         p.RecvId,
+		RecvIds:        p.RecvIds,
+		SendTime:       p.SendTime,
+		MsgId:          p.MsgId,
+		ReadCounts:     p.ReadCounts,
+		Reactions:      reactionsFromProto(p.Reactions),
+		ContentType:    constants.ContentType(p.ContentType),
+		MType:          constants.MType(p.MType),
+		Content:        p.Content,
+		Payload:        payloadFromProto(p.Payload),
+		ReplyTo:        p.ReplyTo,
+		Mentions:       p.Mentions,
+		MentionAll:     p.MentionAll,
+		EditedAt:       p.EditedAt,
+		Muted:          p.Muted,
+		ReadSeq:        p.ReadSeq,
+		Unread:         p.Unread,
+		Typing:         p.Typing,
+		Online:         p.Online,
+		LastSeen:       p.LastSeen,
+	}
+}
+
+func markReadToProto(m *MarkRead) *wspb.MarkRead {
+	return &wspb.MarkRead{
+		ChatType:       int32(m.ChatType),
+		RecvId:         m.RecvId,
+		ConversationId: m.ConversationId,
+		MsgIds:         m.MsgIds,
+		Seq:            m.Seq,
+	}
+}
+
+func markReadFromProto(m *wspb.MarkRead) *MarkRead {
+	return &MarkRead{
+		ChatType:       constants.ChatType(m.ChatType),
+		RecvId:         m.RecvId,
+		ConversationId: m.ConversationId,
+		MsgIds:         m.MsgIds,
+		Seq:            m.Seq,
+	}
+}
+
+func payloadToProto(p *Payload) *wspb.Payload {
+	if p == nil {
+		return nil
+	}
+	return &wspb.Payload{
+		FileId:    p.FileId,
+		Url:       p.Url,
+		Thumb:     p.Thumb,
+		Width:     p.Width,
+		Height:    p.Height,
+		Name:      p.Name,
+		Size:      p.Size,
+		Mime:      p.Mime,
+		Duration:  p.Duration,
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		Address:   p.Address,
+		CardType:  int32(p.CardType),
+		TargetId:  p.TargetId,
+		Avatar:    p.Avatar,
+	}
+}
+
+func payloadFromProto(p *wspb.Payload) *Payload {
+	if p == nil {
+		return nil
+	}
+	return &Payload{
+		FileId:    p.FileId,
+		Url:       p.Url,
+		Thumb:     p.Thumb,
+		Width:     p.Width,
+		Height:    p.Height,
+		Name:      p.Name,
+		Size:      p.Size,
+		Mime:      p.Mime,
+		Duration:  p.Duration,
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		Address:   p.Address,
+		CardType:  constants.CardType(p.CardType),
+		TargetId:  p.TargetId,
+		Avatar:    p.Avatar,
+	}
+}
+
+// reactionsToProto 转换表情回应：消息ID -> 表情 -> 回应的用户ID。
+func reactionsToProto(reactions map[string]map[string][]string) map[string]*wspb.Reaction {
+	if len(reactions) == 0 {
+		return nil
+	}
+	res := make(map[string]*wspb.Reaction, len(reactions))
+	for msgId, emojis := range reactions {
+		r := &wspb.Reaction{Users: make(map[string]*wspb.UserIds, len(emojis))}
+		for emoji, uids := range emojis {
+			r.Users[emoji] = &wspb.UserIds{Ids: uids}
+		}
+		res[msgId] = r
+	}
+	return res
+}
+
+func reactionsFromProto(reactions map[string]*wspb.Reaction) map[string]map[string][]string {
+	if len(reactions) == 0 {
+		return nil
+	}
+	res := make(map[string]map[string][]string, len(reactions))
+	for msgId, r := range reactions {
+		emojis := make(map[string][]string, len(r.GetUsers()))
+		for emoji, uids := range r.GetUsers() {
+			emojis[emoji] = uids.GetIds()
+		}
+		res[msgId] = emojis
+	}
+	return res
+}
@@ -0,0 +1,134 @@
+package ws
+
+import (
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/storage"
+	"errors"
+)
+
+var (
+	ErrMsgContentEmpty = errors.New("消息内容不能为空")
+	ErrMsgPayloadEmpty = errors.New("消息缺少附加内容")
+	ErrMsgTypeInvalid  = errors.New("不支持的消息类型")
+	ErrMsgUrlEmpty     = errors.New("消息缺少资源地址")
+	ErrMsgFileId       = errors.New("消息引用的附件ID无效")
+	ErrMsgSizeInvalid  = errors.New("消息的尺寸或大小无效")
+	ErrMsgFileName     = errors.New("文件消息缺少文件名")
+	ErrMsgDuration     = errors.New("音视频消息的时长无效")
+	ErrMsgLocation     = errors.New("地理位置的经纬度无效")
+	ErrMsgCardInvalid  = errors.New("名片消息的类型或目标无效")
+)
+
+// Payload 表示非文本消息的结构化内容。
+//
+// 不同类型的消息使用其中不同的字段：
+//   - 图片: FileId 或 Url、Width、Height、Thumb
+//   - 文件: FileId 或 Url、Name、Size、Mime
+//   - 语音: FileId 或 Url、Duration、Size
+//   - 视频: FileId 或 Url、Duration、Width、Height、Thumb、Size
+//   - 地理位置: Latitude、Longitude、Name、Address
+//   - 名片: CardType、TargetId、Name、Avatar
+//
+// 附件上传到附件存储后，消息通过 FileId 引用附件，也可以使用外部的资源地址 Url。
+// 引用附件的图片消息由服务端根据附件信息自动填写 Width、Height 与 Thumb。
+// 消息的 Content 字段对于非文本消息是可选的说明文字。
+type Payload struct {
+	FileId    string             `mapstructure:"fileId"`    // 附件ID，通过附件存储上传后得到
+	Url       string             `mapstructure:"url"`       // 资源地址
+	Thumb     string             `mapstructure:"thumb"`     // 缩略图地址，引用附件时为缩略图的附件ID
+	Width     int32              `mapstructure:"width"`     // 图片、视频宽度
+	Height    int32              `mapstructure:"height"`    // 图片、视频高度
+	Name      string             `mapstructure:"name"`      // 文件名、地点名称或名片名称
+	Size      int64              `mapstructure:"size"`      // 资源大小，单位字节
+	Mime      string             `mapstructure:"mime"`      // 文件的 MIME 类型
+	Duration  int32              `mapstructure:"duration"`  // 语音、视频时长，单位秒
+	Latitude  float64            `mapstructure:"latitude"`  // 纬度
+	Longitude float64            `mapstructure:"longitude"` // 经度
+	Address   string             `mapstructure:"address"`   // 详细地址
+	CardType  constants.CardType `mapstructure:"cardType"`  // 名片类型
+	TargetId  string             `mapstructure:"targetId"`  // 名片对应的用户ID或群ID
+	Avatar    string             `mapstructure:"avatar"`    // 名片头像
+}
+
+// Validate 校验消息内容是否与消息类型相符。
+//
+// 文本消息要求 Content 非空，其余类型的消息要求携带对应的结构化内容。
+//
+// 返回:
+//   - error: 消息内容不合法时返回对应的错误。
+func (m *Msg) Validate() error {
+	if m.MType == constants.TextMType {
+		if m.Content == "" {
+			return ErrMsgContentEmpty
+		}
+		return nil
+	}
+
+	if m.MType < constants.TextMType || m.MType > constants.CardMType {
+		return ErrMsgTypeInvalid
+	}
+	p := m.Payload
+	if p == nil {
+		return ErrMsgPayloadEmpty
+	}
+
+	switch m.MType {
+	case constants.ImageMType:
+		if err := p.validSource(); err != nil {
+			return err
+		}
+		if p.Width < 0 || p.Height < 0 {
+			return ErrMsgSizeInvalid
+		}
+	case constants.FileMType:
+		if err := p.validSource(); err != nil {
+			return err
+		}
+		if p.Name == "" {
+			return ErrMsgFileName
+		}
+		if p.Size <= 0 {
+			return ErrMsgSizeInvalid
+		}
+	case constants.VoiceMType:
+		if err := p.validSource(); err != nil {
+			return err
+		}
+		if p.Duration <= 0 {
+			return ErrMsgDuration
+		}
+	case constants.VideoMType:
+		if err := p.validSource(); err != nil {
+			return err
+		}
+		if p.Duration <= 0 {
+			return ErrMsgDuration
+		}
+		if p.Width < 0 || p.Height < 0 || p.Size < 0 {
+			return ErrMsgSizeInvalid
+		}
+	case constants.LocationMType:
+		if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+			return ErrMsgLocation
+		}
+	case constants.CardMType:
+		if p.TargetId == "" || (p.CardType != constants.UserCardType && p.CardType != constants.GroupCardType) {
+			return ErrMsgCardInvalid
+		}
+	}
+	return nil
+}
+
+// validSource 校验附件类消息的资源来源，附件ID与资源地址至少需要一个。
+func (p *Payload) validSource() error {
+	if p.FileId != "" {
+		if !storage.ValidId(p.FileId) {
+			return ErrMsgFileId
+		}
+		return nil
+	}
+	if p.Url == "" {
+		return ErrMsgUrlEmpty
+	}
+	return nil
+}
@@ -6,10 +6,16 @@ import "easy-chat/pkg/constants"
 //
 // 该结构体包含消息的唯一标识符、已读记录、消息类型和消息内容。
 type Msg struct {
-	MsgId           string                 `mapstructure:"msgId"`       // 消息的唯一标识符
-	ReadRecords     map[string]string      `mapstructure:"readRecords"` // 消息的已读记录，键为用户ID，值为已读时间戳
-	constants.MType `mapstructure:"mType"` // 消息的类型，定义在 constants 中
-	Content         string                 `mapstructure:"content"` // 消息的实际内容
+	MsgId           string                         `mapstructure:"msgId"`      // 消息的唯一标识符
+	ReadCounts      map[string]int64               `mapstructure:"readCounts"` // 消息的已读人数，键为消息ID，值为不含发送者的已读人数
+	Reactions       map[string]map[string][]string `mapstructure:"reactions"`  // 消息的表情回应，键为消息ID，值为表情到回应用户ID的映射
+	constants.MType `mapstructure:"mType"`         // 消息的类型，定义在 constants 中
+	Content         string                         `mapstructure:"content"`    // 消息的实际内容，非文本消息为可选的说明文字
+	Payload         *Payload                       `mapstructure:"payload"`    // 非文本消息的结构化内容
+	ReplyTo         string                         `mapstructure:"replyTo"`    // 引用回复的消息ID，必须属于同一会话
+	Mentions        []string                       `mapstructure:"mentions"`   // 群聊中@的成员ID
+	MentionAll      bool                           `mapstructure:"mentionAll"` // 群聊中@所有人，仅群主与管理员可用
+	EditedAt        int64                          `mapstructure:"editedAt"`   // 消息最后一次编辑的时间戳，未编辑过为 0
 }
 
 // Chat 表示一个聊天消息的结构体。
@@ -17,10 +23,18 @@ type Msg struct {
 // 该结构体继承了 Msg 结构体，包含了会话ID、聊天类型、发送者和接收者ID、发送时间等信息。
 type Chat struct {
 	ConversationId     string                    `mapstructure:"conversationId"` // 聊天会话的唯一标识符
+	Seq                int64                     `mapstructure:"seq"`            // 消息在会话内的序号，严格递增
 	constants.ChatType `mapstructure:"chatType"` // 聊天的类型，定义在 constants 中
-	SendId             string                    `mapstructure:"sendId"`   // 发送者的唯一标识符
-	RecvId             string                    `mapstructure:"recvId"`   // 接收者的唯一标识符
-	SendTime           int64                     `mapstructure:"sendTime"` // 消息发送的时间戳
+	SendId             string                    `mapstructure:"sendId"`      // 发送者的唯一标识符
+	RecvId             string                    `mapstructure:"recvId"`      // 接收者的唯一标识符
+	SendTime           int64                     `mapstructure:"sendTime"`    // 消息发送的时间戳
+	ContentType        constants.ContentType     `mapstructure:"contentType"` // 推送内容的类型，例如聊天消息、已读、撤回通知
+	Silent             bool                      `mapstructure:"silent"`      // 接收者对会话开启了免打扰，客户端不应提醒，但仍需计入未读
+	ReadSeq            int64                     `mapstructure:"readSeq"`     // 未读数变更通知中为接收者的已读序号，单聊已读回执中为对方的已读序号
+	Unread             int64                     `mapstructure:"unread"`      // 未读数变更通知中，接收者在会话中的未读消息数
+	Typing             bool                      `mapstructure:"typing"`      // 输入状态通知中，发送者是否正在输入
+	Online             bool                      `mapstructure:"online"`      // 在线状态通知中，发送者是否在线
+	LastSeen           int64                     `mapstructure:"lastSeen"`    // 在线状态通知中，发送者最后在线的时间戳
 	Msg                `mapstructure:"msg"`      // 嵌入的消息结构体，包含消息的详细信息
 }
 
@@ -29,18 +43,34 @@ type Chat struct {
 // 该结构体包含了推送消息所需的信息，包括会话ID、发送者和接收者ID列表、发送时间、消息内容等。
 type Push struct {
 	ConversationId     string                    `mapstructure:"conversationId"` // 推送消息所属的会话ID
+	Seq                int64                     `mapstructure:"seq"`            // 消息在会话内的序号，严格递增
 	constants.ChatType `mapstructure:"chatType"` // 推送消息的聊天类型
 	SendId             string                    `mapstructure:"sendId"`   // 推送消息的发送者ID
 	RecvId             string                    `mapstructure:"recvId"`   // 单一接收者的ID
 	RecvIds            []string                  `mapstructure:"recvIds"`  // 多个接收者的ID列表
 	SendTime           int64                     `mapstructure:"sendTime"` // 推送消息发送的时间戳
 
-	MsgId       string                `mapstructure:"msgId"`       // 消息的唯一标识符
-	ReadRecords map[string]string     `mapstructure:"readRecords"` // 消息的已读记录，键为用户ID，值为已读时间戳
-	ContentType constants.ContentType `mapstructure:"contentType"` // 消息内容的类型，定义在 constants 中
+	MsgId       string                         `mapstructure:"msgId"`       // 消息的唯一标识符
+	ReadCounts  map[string]int64               `mapstructure:"readCounts"`  // 消息的已读人数，键为消息ID，值为不含发送者的已读人数
+	Reactions   map[string]map[string][]string `mapstructure:"reactions"`   // 消息的表情回应，键为消息ID，值为表情到回应用户ID的映射
+	ContentType constants.ContentType          `mapstructure:"contentType"` // 消息内容的类型，定义在 constants 中
 
 	constants.MType `mapstructure:"mType"` // 消息的类型，定义在 constants 中
-	Content         string                 `mapstructure:"content"` // 推送消息的实际内容
+	Content         string                 `mapstructure:"content"`    // 推送消息的实际内容
+	Payload         *Payload               `mapstructure:"payload"`    // 非文本消息的结构化内容
+	ReplyTo         string                 `mapstructure:"replyTo"`    // 引用回复的消息ID
+	Mentions        []string               `mapstructure:"mentions"`   // 群聊中@的成员ID
+	MentionAll      bool                   `mapstructure:"mentionAll"` // 群聊中@所有人
+	EditedAt        int64                  `mapstructure:"editedAt"`   // 消息最后一次编辑的时间戳
+
+	Muted []string `mapstructure:"muted"` // 对会话开启了免打扰的接收者ID
+
+	ReadSeq int64 `mapstructure:"readSeq"` // 未读数变更通知中为接收者的已读序号，单聊已读回执中为对方的已读序号
+	Unread  int64 `mapstructure:"unread"`  // 未读数变更通知中，接收者在会话中的未读消息数
+
+	Typing   bool  `mapstructure:"typing"`   // 输入状态通知中，发送者是否正在输入
+	Online   bool  `mapstructure:"online"`   // 在线状态通知中，发送者是否在线
+	LastSeen int64 `mapstructure:"lastSeen"` // 在线状态通知中，发送者最后在线的时间戳
 }
 
 // MarkRead 表示一个标记消息已读的结构体。
@@ -51,4 +81,46 @@ type MarkRead struct {
 	RecvId             string                    `mapstructure:"recvId"`         // 已读结果的接收者ID
 	ConversationId     string                    `mapstructure:"conversationId"` // 会话的唯一标识符
 	MsgIds             []string                  `mapstructure:"msgIds"`         // 已读消息的ID列表
+	Seq                int64                     `mapstructure:"seq"`            // 已读到的消息序号，单聊可只携带该值而不携带消息ID
+}
+
+// Typing 表示一个输入状态信令的结构体。
+//
+// 客户端在输入时周期性发送 Typing 为 true 的信令，停止输入或发送消息后发送 Typing 为 false 的信令，
+// 服务端不保证停止信令一定到达，超时未刷新的输入状态会由服务端自动结束。
+type Typing struct {
+	constants.ChatType `mapstructure:"chatType"` // 聊天的类型，定义在 constants 中
+	RecvId             string                    `mapstructure:"recvId"`         // 单聊为对方ID，群聊为群ID
+	ConversationId     string                    `mapstructure:"conversationId"` // 会话ID，未指定时根据聊天类型生成
+	Typing             bool                      `mapstructure:"typing"`         // 是否正在输入
+}
+
+// PresenceSubscribe 表示一个订阅或取消订阅在线状态的结构体。
+type PresenceSubscribe struct {
+	UserIds []string `mapstructure:"userIds"` // 好友ID列表，订阅时为空表示订阅全部好友，取消订阅时为空表示取消全部订阅
+}
+
+// PresenceStatuses 表示订阅在线状态后返回的当前状态。
+type PresenceStatuses struct {
+	ContentType constants.ContentType `mapstructure:"contentType"` // 固定为在线状态变更通知
+	Online      map[string]bool       `mapstructure:"online"`      // 用户ID到是否在线的映射
+	LastSeen    map[string]int64      `mapstructure:"lastSeen"`    // 用户ID到最后在线时间戳的映射，在线时为当前时间
+}
+
+// Recall 表示一个撤回消息的结构体。
+type Recall struct {
+	MsgId string `mapstructure:"msgId"` // 要撤回的消息ID
+}
+
+// Edit 表示一个编辑消息的结构体。
+type Edit struct {
+	MsgId   string `mapstructure:"msgId"`   // 要编辑的消息ID
+	Content string `mapstructure:"content"` // 编辑后的消息内容
+}
+
+// React 表示一个表情回应的结构体。
+type React struct {
+	MsgId  string `mapstructure:"msgId"`  // 要回应的消息ID
+	Emoji  string `mapstructure:"emoji"`  // 表情
+	Remove bool   `mapstructure:"remove"` // 为 true 时移除回应
 }
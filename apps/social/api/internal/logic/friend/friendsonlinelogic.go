@@ -5,7 +5,6 @@ import (
 	"easy-chat/apps/social/api/internal/svc"
 	"easy-chat/apps/social/api/internal/types"
 	"easy-chat/apps/social/rpc/social"
-	"easy-chat/pkg/constants"
 	"easy-chat/pkg/ctxdata"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -30,14 +29,14 @@ func NewFriendsOnlineLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Fri
 // 功能描述:
 //   - 从上下文中获取当前用户ID
 //   - 查询当前用户的所有好友列表
-//   - 查询缓存中在线的用户
-//   - 比较好友列表与缓存中的在线用户列表，返回每个好友的在线状态
+//   - 查询好友的在线状态，在线状态由 im-ws 的连接与心跳维护
+//   - 返回每个好友是否在线以及最后在线时间
 //
 // 参数:
 //   - req: `*types.FriendsOnlineReq` 类型，包含请求参数（当前未使用）
 //
 // 返回值:
-//   - `*types.FriendsOnlineResp`: 响应对象，包含好友在线状态的列表
+//   - `*types.FriendsOnlineResp`: 响应对象，包含好友在线状态与最后在线时间的列表
 //   - `error`: 如果在查询过程中发生错误，则返回相应的错误信息
 func (l *FriendsOnlineLogic) FriendsOnline(req *types.FriendsOnlineReq) (resp *types.FriendsOnlineResp, err error) {
 	// 从上下文中获取当前用户ID
@@ -60,31 +59,24 @@ func (l *FriendsOnlineLogic) FriendsOnline(req *types.FriendsOnlineReq) (resp *t
 	// 提取好友ID列表
 	uids := make([]string, 0, len(friendList.List))
 	for _, friend := range friendList.List {
-		uids = append(uids, friend.UserId)
+		uids = append(uids, friend.FriendUid)
 	}
 
-	// 查询Redis缓存中的在线用户
-	onlines, err := l.svcCtx.Redis.Hgetall(constants.RedisOnlineUser)
+	// 查询好友的在线状态
+	statuses, err := l.svcCtx.Presence.Statuses(l.ctx, uids...)
 	if err != nil {
-		// 如果查询Redis缓存失败，返回错误信息
 		return nil, err
 	}
 
-	// 构建在线状态的映射表
-	resOnlineList := make(map[string]bool, len(uids))
-	for _, uid := range uids {
-		// 检查每个好友ID是否在在线用户列表中
-		if _, ok := onlines[uid]; ok {
-			// 如果好友在在线用户列表中，则标记为在线（即未找到，说明是离线状态）
-			resOnlineList[uid] = true
-		} else {
-			// 如果好友不在在线用户列表中，则标记为离线
-			resOnlineList[uid] = false
-		}
+	resp = &types.FriendsOnlineResp{
+		OnlineList: make(map[string]bool, len(statuses)),
+		LastSeen:   make(map[string]int64, len(statuses)),
+	}
+	for uid, status := range statuses {
+		resp.OnlineList[uid] = status.Online
+		resp.LastSeen[uid] = status.LastSeen
 	}
 
 	// 返回好友在线状态的响应
-	return &types.FriendsOnlineResp{
-		OnlineList: resOnlineList,
-	}, nil
+	return resp, nil
 }
@@ -3,7 +3,6 @@ package group
 import (
 	"context"
 	"easy-chat/apps/social/rpc/socialclient"
-	"easy-chat/pkg/constants"
 
 	"easy-chat/apps/social/api/internal/svc"
 	"easy-chat/apps/social/api/internal/types"
@@ -29,14 +28,14 @@ func NewGroupUserOnlineLogic(ctx context.Context, svcCtx *svc.ServiceContext) *G
 //
 // 功能描述:
 //   - 获取指定群组的所有成员
-//   - 检查这些成员是否在线，依据缓存中的在线用户信息
-//   - 返回每个成员的在线状态
+//   - 查询这些成员的在线状态，在线状态由 im-ws 的连接与心跳维护
+//   - 返回每个成员是否在线以及最后在线时间
 //
 // 参数:
 //   - req: `*types.GroupUserOnlineReq` 类型，包含群组ID，用于指定要查询的群组
 //
 // 返回值:
-//   - `*types.GroupUserOnlineResp`: 包含在线用户的状态映射
+//   - `*types.GroupUserOnlineResp`: 包含成员在线状态与最后在线时间的映射
 //   - `error`: 如果在处理过程中发生错误，则返回相应的错误信息
 func (l *GroupUserOnlineLogic) GroupUserOnline(req *types.GroupUserOnlineReq) (resp *types.GroupUserOnlineResp, err error) {
 	// 获取当前群组的所有成员信息
@@ -59,26 +58,21 @@ func (l *GroupUserOnlineLogic) GroupUserOnline(req *types.GroupUserOnlineReq) (r
 		uids = append(uids, groupUser.UserId)
 	}
 
-	// 查询缓存中所有在线用户的状态
-	onlines, err := l.svcCtx.Redis.Hgetall(constants.RedisOnlineUser)
+	// 查询成员的在线状态
+	statuses, err := l.svcCtx.Presence.Statuses(l.ctx, uids...)
 	if err != nil {
-		// 如果查询缓存失败，则返回空响应和错误
 		return nil, err
 	}
 
-	// 创建一个映射，用于存储每个用户的在线状态
-	resOnLineList := make(map[string]bool, len(uids))
-	for _, s := range uids {
-		// 如果用户ID在缓存中，则表示该用户在线，否则为离线
-		if _, ok := onlines[s]; ok {
-			resOnLineList[s] = true
-		} else {
-			resOnLineList[s] = false
-		}
+	resp = &types.GroupUserOnlineResp{
+		OnlineList: make(map[string]bool, len(statuses)),
+		LastSeen:   make(map[string]int64, len(statuses)),
+	}
+	for uid, status := range statuses {
+		resp.OnlineList[uid] = status.Online
+		resp.LastSeen[uid] = status.LastSeen
 	}
 
 	// 返回群组用户在线状态的响应
-	return &types.GroupUserOnlineResp{
-		OnlineList: resOnLineList, // 在线用户状态映射
-	}, nil
+	return resp, nil
 }
@@ -7,6 +7,7 @@ import (
 	"easy-chat/apps/user/rpc/userclient"
 	"easy-chat/pkg/interceptor"
 	"easy-chat/pkg/middleware"
+	"easy-chat/pkg/presence"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -24,6 +25,8 @@ type ServiceContext struct {
 	userclient.User     // 用户服务客户端
 	imclient.Im         // 即时通讯服务客户端
 	*redis.Redis        // Redis 客户端
+
+	Presence *presence.Store // 用户在线状态，由 im-ws 的连接生命周期维护
 }
 
 // retryPolicy 定义了 gRPC 客户端的重试策略
@@ -45,6 +48,8 @@ var retryPolicy = `{
 
 // NewServiceContext 创建一个新的 ServiceContext 实例
 func NewServiceContext(c config.Config) *ServiceContext {
+	rds := redis.MustNewRedis(c.Redisx)
+
 	return &ServiceContext{
 		Config: c,
 		Social: socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc,
@@ -57,7 +62,8 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		Im: imclient.NewIm(
 			zrpc.MustNewClient(c.ImRpc),
 		),
-		Redis: redis.MustNewRedis(c.Redisx),
+		Redis:    rds,
+		Presence: presence.NewStore(rds, presence.DefaultTTL),
 
 		IdempotenceMiddleware: middleware.NewIdempotenceMiddleware().Handler,
 		LimitMiddleware:       middleware.NewLimitMiddleware(c.Redisx).TokenLimitHandler(100, 100),
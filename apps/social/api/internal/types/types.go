@@ -139,7 +139,8 @@ type FriendsOnlineReq struct {
 }
 
 type FriendsOnlineResp struct {
-	OnlineList map[string]bool `json:"onLineList"`
+	OnlineList map[string]bool  `json:"onLineList"`
+	LastSeen   map[string]int64 `json:"lastSeen"`
 }
 
 type GroupUserOnlineReq struct {
@@ -147,5 +148,6 @@ type GroupUserOnlineReq struct {
 }
 
 type GroupUserOnlineResp struct {
-	OnlineList map[string]bool `json:"onLineList"`
+	OnlineList map[string]bool  `json:"onLineList"`
+	LastSeen   map[string]int64 `json:"lastSeen"`
 }
@@ -13,6 +13,8 @@ type Config struct {
 
 	MsgChatTransfer kq.KqConf
 	MsgReadTransfer kq.KqConf
+	// 已发送消息的变更通知，例如撤回
+	MsgNoticeTransfer kq.KqConf
 
 	Redisx redis.RedisConf
 
@@ -25,6 +27,15 @@ type Config struct {
 
 	Ws struct {
 		Host string
+		// Codec 推送到 im-ws 使用的消息编解码器，json 或 protobuf
+		Codec string `json:",default=json,options=json|protobuf"`
+	}
+
+	// MsgPushTransfer 跨节点推送配置，配置后消息按连接注册表直接投递到接收者所在的 im-ws 节点，
+	// 未配置时仍通过 Ws 客户端推送到单个 im-ws 节点。
+	MsgPushTransfer struct {
+		Topic string   `json:",optional"`
+		Addrs []string `json:",optional"`
 	}
 
 	MsgReadHandler struct {
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/zeromicro/go-zero/core/conf"
+)
+
+func TestLoadConfig(t *testing.T) {
+	for _, file := range []string{"../../etc/dev/task-mq.yaml", "../../etc/conf/task-mq.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			var c Config
+			if err := conf.Load(file, &c); err != nil {
+				t.Fatalf("load config err: %v", err)
+			}
+			if c.MsgNoticeTransfer.Topic != "msgNoticeTransfer" {
+				t.Errorf("MsgNoticeTransfer.Topic = %q, want msgNoticeTransfer", c.MsgNoticeTransfer.Topic)
+			}
+			if c.MsgPushTransfer.Topic != "msgPushTransfer" || len(c.MsgPushTransfer.Addrs) == 0 {
+				t.Errorf("MsgPushTransfer = %+v, want topic msgPushTransfer with addrs", c.MsgPushTransfer)
+			}
+			h := c.MsgReadHandler
+			if h.GroupMsgReadHandler != 1 || h.GroupMsgReadRecordDelayTime != 5 || h.GroupMsgReadRecordDelayCount != 2 {
+				t.Errorf("MsgReadHandler = %+v, want {1 5 2}", h)
+			}
+		})
+	}
+}
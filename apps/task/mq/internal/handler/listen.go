@@ -16,6 +16,7 @@ func (l *Listen) Services() []service.Service {
 		// todo: 此处可以加载多个消费者
 		kq.MustNewQueue(l.svc.Config.MsgReadTransfer, msgtransfer.NewMsgReadTransfer(l.svc)),
 		kq.MustNewQueue(l.svc.Config.MsgChatTransfer, msgtransfer.NewMsgChatTransfer(l.svc)),
+		kq.MustNewQueue(l.svc.Config.MsgNoticeTransfer, msgtransfer.NewMsgNoticeTransfer(l.svc)),
 	}
 }
 
@@ -36,7 +36,7 @@ func newGroupMsgRead(push *ws.Push, pushChan chan *ws.Push) *groupMsgRead {
 	return m
 }
 
-// mergePush 合并消息
+// mergePush 合并消息，已读记录与表情回应均按消息ID合并
 func (g *groupMsgRead) mergePush(push *ws.Push) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -46,8 +46,19 @@ func (g *groupMsgRead) mergePush(push *ws.Push) {
 	}
 
 	g.count++
-	for msgId, read := range push.ReadRecords {
-		g.push.ReadRecords[msgId] = read
+	// 已读人数只增不减，同一消息以较大者为准
+	for msgId, count := range push.ReadCounts {
+		if g.push.ReadCounts == nil {
+			g.push.ReadCounts = make(map[string]int64)
+		}
+		g.push.ReadCounts[msgId] = max(g.push.ReadCounts[msgId], count)
+	}
+	// 表情回应携带的是消息变更后的全部回应，同一消息以最新的为准
+	for msgId, reactions := range push.Reactions {
+		if g.push.Reactions == nil {
+			g.push.Reactions = make(map[string]map[string][]string)
+		}
+		g.push.Reactions[msgId] = reactions
 	}
 }
 
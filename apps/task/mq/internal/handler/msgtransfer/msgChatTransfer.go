@@ -4,12 +4,14 @@ import (
 	"context"
 	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/internal/svc"
 	"easy-chat/apps/task/mq/mq"
-	"easy-chat/pkg/bitmap"
+	"easy-chat/pkg/constants"
 	"encoding/json"
 	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // MsgChatTransfer 处理聊天消息的转发。
@@ -48,22 +50,37 @@ func (m *MsgChatTransfer) Consume(key, value string) error {
 	fmt.Println("key:", key, "value:", value)
 
 	var (
-		data  mq.MsgChatTransfer
-		ctx   = context.Background()
-		msgId = primitive.NewObjectID()
+		data mq.MsgChatTransfer
+		ctx  = context.Background()
 	)
 	// 反序列化数据
 	if err := json.Unmarshal([]byte(value), &data); err != nil {
 		return err
 	}
+	// 使用 im-ws 生成的聊天记录ID，消息重新投递时复用同一条聊天记录与序号；兼容未携带该ID的旧消息
+	msgId, err := primitive.ObjectIDFromHex(data.ChatLogId)
+	if err != nil {
+		msgId = primitive.NewObjectID()
+	}
+	// 群聊消息只查询一次群成员，同时用于过滤@的成员与推送；查询失败时不影响消息保存，推送时重新查询
+	var members []*socialclient.GroupMembers
+	if data.ChatType == constants.GroupChatType {
+		if members, err = m.groupUsers(ctx, data.RecvId); err != nil {
+			m.Errorf("query group users err: %v, groupId: %v", err, data.RecvId)
+		}
+	}
+
 	// 记录数据
-	if err := m.addChatLog(ctx, msgId, &data); err != nil {
+	seq, err := m.addChatLog(ctx, msgId, &data, members)
+	if err != nil {
 		return err
 	}
 
-	return m.Transfer(ctx, &ws.Push{
-		MsgId:          data.MsgId,
+	push := &ws.Push{
+		// 使用聊天记录ID作为消息ID，客户端据此确认送达、标记已读
+		MsgId:          msgId.Hex(),
 		ConversationId: data.ConversationId,
+		Seq:            seq,
 		ChatType:       data.ChatType,
 		SendId:         data.SendId,
 		RecvId:         data.RecvId,
@@ -71,32 +88,162 @@ func (m *MsgChatTransfer) Consume(key, value string) error {
 		SendTime:       data.SendTime,
 		MType:          data.MType,
 		Content:        data.Content,
-	})
+		Payload:        data.Payload,
+		ReplyTo:        data.ReplyTo,
+		Mentions:       data.Mentions,
+		MentionAll:     data.MentionAll,
+	}
+	if members != nil {
+		return m.pushGroup(ctx, push, members)
+	}
+	return m.Transfer(ctx, push)
 }
 
-func (m *MsgChatTransfer) addChatLog(ctx context.Context, msgId primitive.ObjectID, data *mq.MsgChatTransfer) error {
+// addChatLog 持久化聊天消息，并为消息分配会话内的序号。
+//
+// 序号在写入聊天记录前由会话原子递增得到，同一会话内严格递增，
+// 客户端可以根据序号判断离线期间缺失的消息并通过 SyncMessages 拉取。
+// 同一消息重试时复用之前分配的序号，聊天记录已写入时不再重复写入，序号中不会因重试留下空洞。
+// 引用回复的消息必须属于同一会话，否则丢弃引用关系；@的成员按群成员与角色过滤，消息本身照常保存。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - msgId: 消息ID。
+//   - data: 聊天消息数据。
+//   - members: 群聊的群成员，用于过滤@的成员，查询失败时为 nil。
+//
+// 返回值:
+//   - int64: 消息在会话内的序号。
+//   - error: 如果在记录过程中出现错误，返回相应的错误；否则返回 nil。
+func (m *MsgChatTransfer) addChatLog(ctx context.Context, msgId primitive.ObjectID, data *mq.MsgChatTransfer, members []*socialclient.GroupMembers) (int64, error) {
+	// 校验引用回复的消息
+	if data.ReplyTo != "" {
+		if err := m.checkReplyTo(ctx, data); err != nil {
+			m.Errorf("invalid replyTo, drop it, err: %v, msgId: %v, replyTo: %v", err, msgId.Hex(), data.ReplyTo)
+			data.ReplyTo = ""
+		}
+	}
+
+	// 校验@的成员
+	if len(data.Mentions) > 0 || data.MentionAll {
+		m.checkMentions(data, members)
+	}
+
+	// 分配会话内的消息序号
+	seq, err := m.svcCtx.ConversationModel.IncrSeq(ctx, data.ConversationId, msgId)
+	if err != nil {
+		return 0, err
+	}
+
 	// 记录消息
 	chatLog := immodels.ChatLog{
 		ID:             msgId,
 		ConversationId: data.ConversationId,
+		Seq:            seq,
 		SendId:         data.SendId,
 		RecvId:         data.RecvId,
 		ChatType:       data.ChatType,
 		MsgFrom:        0,
 		MsgType:        data.MType,
 		MsgContent:     data.Content,
+		Payload:        toMsgPayload(data.Payload),
+		ReplyTo:        data.ReplyTo,
+		Mentions:       data.Mentions,
+		MentionAll:     data.MentionAll,
 		SendTime:       data.SendTime,
 	}
 
-	// 得到默认已读的对象
-	readRecord := bitmap.NewBitmap(0)
-	// 发送消息者默认已读
-	readRecord.Set(chatLog.SendId)
-	chatLog.ReadRecords = readRecord.Export()
+	// 聊天记录已存在说明之前的处理在写入后失败，继续完成后续步骤
+	if err := m.svcCtx.ChatLogModel.Insert(ctx, &chatLog); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return 0, err
+	}
+	if err := m.svcCtx.ConversationModel.UpdateMsg(ctx, &chatLog); err != nil {
+		return 0, err
+	}
+
+	// 发送者自己发送的消息视为已读
+	if _, err := m.svcCtx.ConversationsModel.UpdateReadSeq(ctx, data.SendId, data.ConversationId, seq); err != nil {
+		m.Errorf("update sender read seq err: %v, msgId: %v", err, msgId.Hex())
+	}
+	return seq, nil
+}
 
-	err := m.svcCtx.ChatLogModel.Insert(ctx, &chatLog)
+// checkReplyTo 校验引用回复的消息存在且属于同一会话。
+func (m *MsgChatTransfer) checkReplyTo(ctx context.Context, data *mq.MsgChatTransfer) error {
+	replyTo, err := m.svcCtx.ChatLogModel.FindOne(ctx, data.ReplyTo)
 	if err != nil {
 		return err
 	}
-	return m.svcCtx.ConversationModel.UpdateMsg(ctx, &chatLog)
+	if replyTo.ConversationId != data.ConversationId {
+		return fmt.Errorf("replyTo belongs to conversation %s", replyTo.ConversationId)
+	}
+	return nil
+}
+
+// checkMentions 过滤消息中@的成员。
+//
+// 只有群聊消息可以@成员：@的成员必须是群成员且不能是发送者自己，重复的成员只保留一个；
+// @所有人仅群主与管理员可用，普通成员的@所有人会被忽略。
+// 群成员查询失败（members 为 nil）时无法校验，丢弃消息中全部的@，消息本身照常保存。
+func (m *MsgChatTransfer) checkMentions(data *mq.MsgChatTransfer, users []*socialclient.GroupMembers) {
+	if data.ChatType != constants.GroupChatType {
+		data.Mentions, data.MentionAll = nil, false
+		return
+	}
+	if users == nil {
+		m.Infof("group users unavailable, drop mentions, sendId: %v, groupId: %v", data.SendId, data.RecvId)
+		data.Mentions, data.MentionAll = nil, false
+		return
+	}
+
+	members := make(map[string]constants.GroupRoleLevel, len(users))
+	for _, user := range users {
+		members[user.UserId] = constants.GroupRoleLevel(user.RoleLevel)
+	}
+
+	if data.MentionAll {
+		role := members[data.SendId]
+		if role != constants.CreatorGroupRoleLevel && role != constants.ManagerGroupRoleLevel {
+			m.Infof("mention all not allowed, sendId: %v, groupId: %v", data.SendId, data.RecvId)
+			data.MentionAll = false
+		}
+	}
+
+	mentions := make([]string, 0, len(data.Mentions))
+	seen := make(map[string]struct{}, len(data.Mentions))
+	for _, uid := range data.Mentions {
+		if _, ok := members[uid]; !ok || uid == data.SendId {
+			continue
+		}
+		if _, ok := seen[uid]; ok {
+			continue
+		}
+		seen[uid] = struct{}{}
+		mentions = append(mentions, uid)
+	}
+	data.Mentions = mentions
+}
+
+// toMsgPayload 将消息队列中的结构化消息内容转换为聊天记录中保存的格式。
+func toMsgPayload(p *ws.Payload) *immodels.MsgPayload {
+	if p == nil {
+		return nil
+	}
+	return &immodels.MsgPayload{
+		FileId:    p.FileId,
+		Url:       p.Url,
+		Thumb:     p.Thumb,
+		Width:     p.Width,
+		Height:    p.Height,
+		Name:      p.Name,
+		Size:      p.Size,
+		Mime:      p.Mime,
+		Duration:  p.Duration,
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		Address:   p.Address,
+		CardType:  p.CardType,
+		TargetId:  p.TargetId,
+		Avatar:    p.Avatar,
+	}
 }
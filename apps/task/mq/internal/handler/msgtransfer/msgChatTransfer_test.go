@@ -0,0 +1,165 @@
+package msgtransfer
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeConversationModel 在内存中按消息ID分配序号，同一消息重复分配时返回之前的序号
+type fakeConversationModel struct {
+	immodels.ConversationModel
+	seq        int64
+	allocs     map[primitive.ObjectID]int64
+	updateErrs int
+}
+
+func (m *fakeConversationModel) IncrSeq(ctx context.Context, conversationId string, msgId primitive.ObjectID) (int64, error) {
+	if seq, ok := m.allocs[msgId]; ok {
+		return seq, nil
+	}
+	m.seq++
+	m.allocs[msgId] = m.seq
+	return m.seq, nil
+}
+
+func (m *fakeConversationModel) UpdateMsg(ctx context.Context, chatLog *immodels.ChatLog) error {
+	if m.updateErrs > 0 {
+		m.updateErrs--
+		return errors.New("update conversation failed")
+	}
+	return nil
+}
+
+// fakeChatLogModel 在内存中保存聊天记录，前 insertErrs 次写入失败，重复写入返回唯一索引冲突
+type fakeChatLogModel struct {
+	immodels.ChatLogModel
+	logs       map[primitive.ObjectID]*immodels.ChatLog
+	insertErrs int
+}
+
+func (m *fakeChatLogModel) Insert(ctx context.Context, data *immodels.ChatLog) error {
+	if m.insertErrs > 0 {
+		m.insertErrs--
+		return errors.New("insert chat log failed")
+	}
+	if _, ok := m.logs[data.ID]; ok {
+		return mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+	}
+	m.logs[data.ID] = data
+	return nil
+}
+
+type fakeConversationsModel struct {
+	immodels.ConversationsModel
+}
+
+func (m *fakeConversationsModel) UpdateReadSeq(ctx context.Context, uid, conversationId string, readSeq int64) (bool, error) {
+	return true, nil
+}
+
+func TestMsgChatTransfer_AddChatLogRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		insertErrs int
+		updateErrs int
+	}{
+		{name: "insert failed", insertErrs: 1},
+		{name: "update conversation failed", updateErrs: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversations := &fakeConversationModel{allocs: map[primitive.ObjectID]int64{}, updateErrs: tt.updateErrs}
+			chatLogs := &fakeChatLogModel{logs: map[primitive.ObjectID]*immodels.ChatLog{}, insertErrs: tt.insertErrs}
+			transfer := NewMsgChatTransfer(&svc.ServiceContext{
+				ChatLogModel:       chatLogs,
+				ConversationModel:  conversations,
+				ConversationsModel: &fakeConversationsModel{},
+			})
+
+			ctx := context.Background()
+			msgId := primitive.NewObjectID()
+			data := &mq.MsgChatTransfer{
+				ConversationId: "c1",
+				ChatType:       constants.SingleChatType,
+				SendId:         "u1",
+				RecvId:         "u2",
+				Content:        "hello",
+			}
+
+			if _, err := transfer.addChatLog(ctx, msgId, data, nil); err == nil {
+				t.Fatal("first attempt should fail")
+			}
+			seq, err := transfer.addChatLog(ctx, msgId, data, nil)
+			if err != nil {
+				t.Fatalf("retry err: %v", err)
+			}
+			if seq != 1 {
+				t.Fatalf("retry seq = %d, want 1", seq)
+			}
+			if len(chatLogs.logs) != 1 || chatLogs.logs[msgId].Seq != 1 {
+				t.Fatalf("chat logs = %v, want one log with seq 1", chatLogs.logs)
+			}
+
+			// 重试没有占用新的序号，下一条消息的序号连续
+			seq, err = transfer.addChatLog(ctx, primitive.NewObjectID(), data, nil)
+			if err != nil {
+				t.Fatalf("next message err: %v", err)
+			}
+			if seq != 2 {
+				t.Fatalf("next message seq = %d, want 2", seq)
+			}
+		})
+	}
+}
+
+func TestMsgChatTransfer_CheckMentions(t *testing.T) {
+	users := []*socialclient.GroupMembers{
+		{UserId: "u1", RoleLevel: int32(constants.CreatorGroupRoleLevel)},
+		{UserId: "u2", RoleLevel: int32(constants.AtLargeGroupRoleLevel)},
+		{UserId: "u3", RoleLevel: int32(constants.AtLargeGroupRoleLevel)},
+	}
+	tests := []struct {
+		name       string
+		sendId     string
+		users      []*socialclient.GroupMembers
+		mentions   []string
+		mentionAll bool
+		want       []string
+		wantAll    bool
+	}{
+		{"过滤非成员、自己与重复的成员", "u1", users, []string{"u2", "u4", "u1", "u2", "u3"}, true, []string{"u2", "u3"}, true},
+		{"普通成员不能@所有人", "u2", users, []string{"u3"}, true, []string{"u3"}, false},
+		{"群成员查询失败时丢弃@", "u1", nil, []string{"u2"}, true, nil, false},
+	}
+
+	transfer := NewMsgChatTransfer(&svc.ServiceContext{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &mq.MsgChatTransfer{
+				ChatType:   constants.GroupChatType,
+				SendId:     tt.sendId,
+				RecvId:     "g1",
+				Mentions:   tt.mentions,
+				MentionAll: tt.mentionAll,
+			}
+			transfer.checkMentions(data, tt.users)
+			if !reflect.DeepEqual(data.Mentions, tt.want) {
+				t.Errorf("mentions = %v, want %v", data.Mentions, tt.want)
+			}
+			if data.MentionAll != tt.wantAll {
+				t.Errorf("mentionAll = %v, want %v", data.MentionAll, tt.wantAll)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package msgtransfer
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+	"sync"
+)
+
+// MsgNoticeTransfer 处理已发送消息的变更通知（例如撤回、编辑、表情回应）的转发。
+//
+// 群聊中的表情回应变更与已读回执一样，在开启合并推送时按会话合并后再推送，
+// 避免热门群中频繁的回应与取消回应造成推送风暴。
+type MsgNoticeTransfer struct {
+	*baseMsgTransfer
+
+	mu sync.Mutex
+
+	groupMsgs map[string]*groupMsgRead
+	push      chan *ws.Push
+}
+
+// NewMsgNoticeTransfer 创建一个新的 MsgNoticeTransfer 实例。
+//
+// 参数:
+//   - svc: 服务上下文对象，包含了服务配置和依赖的服务实例。
+//
+// 返回值:
+//   - *MsgNoticeTransfer: 初始化好的消息变更通知转发器实例。
+func NewMsgNoticeTransfer(svc *svc.ServiceContext) *MsgNoticeTransfer {
+	m := &MsgNoticeTransfer{
+		baseMsgTransfer: NewBaseMsgTransfer(svc),
+		groupMsgs:       make(map[string]*groupMsgRead, 1),
+		push:            make(chan *ws.Push, 1),
+	}
+
+	go m.transfer()
+
+	return m
+}
+
+// Consume 处理从消息队列中消费的消息变更通知。
+//
+// 消息的变更已经由 im rpc 服务持久化，这里只负责将通知推送给原消息的接收者：
+// 单聊推送给对方，群聊推送给除操作者以外的群成员；群聊的表情回应变更按会话合并后推送给全部群成员。
+//
+// 参数:
+//   - key: 消息队列中的键值。
+//   - value: 消息变更通知，以 JSON 格式存储。
+//
+// 返回值:
+//   - error: 如果在处理过程中出现错误，返回相应的错误；否则返回 nil。
+func (m *MsgNoticeTransfer) Consume(key, value string) error {
+	m.Infof("MsgNoticeTransfer.Consume value: %v", value)
+
+	var data mq.MsgNotice
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return err
+	}
+
+	push := &ws.Push{
+		ConversationId: data.ConversationId,
+		Seq:            data.Seq,
+		ChatType:       data.ChatType,
+		SendId:         data.SendId,
+		RecvId:         data.RecvId,
+		MsgId:          data.MsgId,
+		ContentType:    data.ContentType,
+		Content:        data.Content,
+		EditedAt:       data.EditedAt,
+	}
+	if data.ContentType != constants.ContentReaction {
+		return m.Transfer(context.Background(), push)
+	}
+
+	push.Reactions = map[string]map[string][]string{
+		data.MsgId: data.Reactions,
+	}
+	if data.ChatType != constants.GroupChatType ||
+		m.svcCtx.Config.MsgReadHandler.GroupMsgReadHandler == GroupMsgReadHandlerAtTransfer {
+		m.push <- push
+		return nil
+	}
+
+	// 合并推送
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	push.SendId = "" // 合并后包含多个用户的回应，推送给全部群成员
+	if g, ok := m.groupMsgs[push.ConversationId]; ok {
+		m.Infof("merge reaction push: %v", push.ConversationId)
+		g.mergePush(push)
+	} else {
+		m.Infof("create merge reaction push %v", push.ConversationId)
+		m.groupMsgs[push.ConversationId] = newGroupMsgRead(push, m.push)
+	}
+	return nil
+}
+
+// transfer 异步推送表情回应变更，并清理空闲的合并推送。
+func (m *MsgNoticeTransfer) transfer() {
+	for push := range m.push {
+		if push.RecvId != "" || len(push.RecvIds) > 0 {
+			if err := m.Transfer(context.Background(), push); err != nil {
+				m.Errorf("transfer err: %s", err.Error())
+			}
+		}
+		if push.ChatType == constants.SingleChatType {
+			continue
+		}
+		// 不采用合并推送
+		if m.svcCtx.Config.MsgReadHandler.GroupMsgReadHandler == GroupMsgReadHandlerAtTransfer {
+			continue
+		}
+		// 清空数据
+		m.mu.Lock()
+		if g, ok := m.groupMsgs[push.ConversationId]; ok && g.IsIdle() {
+			g.Clear()
+			delete(m.groupMsgs, push.ConversationId)
+		}
+		m.mu.Unlock()
+	}
+}
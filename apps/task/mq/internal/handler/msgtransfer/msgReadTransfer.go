@@ -2,12 +2,12 @@ package msgtransfer
 
 import (
 	"context"
+	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/internal/svc"
 	"easy-chat/apps/task/mq/mq"
-	"easy-chat/pkg/bitmap"
 	"easy-chat/pkg/constants"
-	"encoding/base64"
 	"encoding/json"
 	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/core/stores/cache"
@@ -71,17 +71,30 @@ func (m *MsgReadTransfer) Consume(key, value string) error {
 		return err
 	}
 	// 发送给消费者后，更新用户已读未读的记录
-	readRecords, err := m.UpdateChatLogRead(ctx, &data)
+	readCounts, readSeq, err := m.UpdateChatLogRead(ctx, &data)
 	if err != nil {
 		return err
 	}
+	// 推进用户的已读序号，并将新的未读数同步到用户的所有设备
+	readSeq, changed, err := m.updateReadSeq(ctx, &data, max(readSeq, data.Seq))
+	if err != nil {
+		return err
+	}
+	// 单聊只推送已读位置，已读位置没有变化时不需要通知对方
+	if data.ChatType == constants.SingleChatType {
+		if !changed {
+			return nil
+		}
+		readCounts = nil
+	}
 	push := &ws.Push{
 		ConversationId: data.ConversationId,
 		ChatType:       data.ChatType,
 		SendId:         data.SendId,
 		RecvId:         data.RecvId,
 		ContentType:    constants.ContentMakeRead,
-		ReadRecords:    readRecords,
+		ReadCounts:     readCounts,
+		ReadSeq:        readSeq,
 	}
 	// 判断消息类型
 	switch data.ChatType {
@@ -113,31 +126,119 @@ func (m *MsgReadTransfer) Consume(key, value string) error {
 	return nil
 }
 
-func (m *MsgReadTransfer) UpdateChatLogRead(ctx context.Context, data *mq.MsgMarkRead) (map[string]string, error) {
-	result := make(map[string]string)
+// UpdateChatLogRead 更新消息的已读记录，返回新增了已读记录的消息的已读人数，以及标记的消息在会话中的最大序号。
+//
+// 群聊消息的已读记录为精确的已读用户列表，发送者不计入已读人数；仍为旧版本位图格式的消息会先迁移再记录。
+// 单聊消息不逐条记录，已读状态由接收者在会话中的已读序号决定。
+func (m *MsgReadTransfer) UpdateChatLogRead(ctx context.Context, data *mq.MsgMarkRead) (map[string]int64, int64, error) {
+	var readSeq int64
+	result := make(map[string]int64)
 	chatLogs, err := m.svcCtx.ChatLogModel.ListByMsgIds(ctx, data.MsgIds)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	// 处理已读消息
 	for _, chatLog := range chatLogs {
-		switch chatLog.ChatType {
-		case constants.SingleChatType:
-			chatLog.ReadRecords = []byte{1}
-		case constants.GroupChatType:
-			// 设置当前发送者用户为已读状态
-			readRecords := bitmap.Load(chatLog.ReadRecords)
-			readRecords.Set(data.SendId)
-			chatLog.ReadRecords = readRecords.Export()
-		}
-		result[chatLog.ID.Hex()] = base64.StdEncoding.EncodeToString(chatLog.ReadRecords)
-
-		err := m.svcCtx.ChatLogModel.UpdateMakeRead(ctx, chatLog.ID, chatLog.ReadRecords)
+		if chatLog.ConversationId != data.ConversationId {
+			continue
+		}
+		readSeq = max(readSeq, chatLog.Seq)
+		// 发送者自己的消息不需要记录已读，单聊消息只记录已读序号
+		if chatLog.SendId == data.SendId || chatLog.ChatType == constants.SingleChatType {
+			continue
+		}
+
+		if chatLog.HasLegacyReadRecords() {
+			if err := m.migrateReadRecords(ctx, chatLog); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		count, ok, err := m.svcCtx.ChatLogModel.MarkRead(ctx, chatLog.ID, data.SendId)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			result[chatLog.ID.Hex()] = count
+		}
+	}
+	return result, readSeq, nil
+}
+
+// migrateReadRecords 将消息旧版本的位图已读记录迁移为精确的已读用户列表。
+func (m *MsgReadTransfer) migrateReadRecords(ctx context.Context, chatLog *immodels.ChatLog) error {
+	var members []string
+	if chatLog.ChatType == constants.GroupChatType {
+		users, err := m.svcCtx.Social.GroupUsers(ctx, &socialclient.GroupUsersReq{
+			GroupId: chatLog.RecvId,
+		})
 		if err != nil {
-			return nil, err
+			return err
 		}
+		for _, user := range users.List {
+			members = append(members, user.UserId)
+		}
+	}
+	return m.svcCtx.ChatLogModel.MigrateReadRecords(ctx, chatLog.ID, chatLog.LegacyReadBy(members))
+}
+
+// updateReadSeq 推进用户在会话中的已读序号，已读序号发生变化时向用户的所有设备推送新的未读数。
+//
+// 用户阅读消息时按顺序阅读，标记某条消息已读意味着该消息及之前的消息都已读。
+// 已读序号不会超过会话当前的最大序号。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - data: 标记已读的请求。
+//   - readSeq: 标记已读的消息在会话中的最大序号。
+//
+// 返回值:
+//   - int64: 用户当前的已读序号。
+//   - bool: 已读序号是否发生变化。
+//   - error: 更新已读序号失败时返回错误。
+func (m *MsgReadTransfer) updateReadSeq(ctx context.Context, data *mq.MsgMarkRead, readSeq int64) (int64, bool, error) {
+	if readSeq <= 0 {
+		return 0, false, nil
+	}
+	list, err := m.svcCtx.ConversationModel.ListByConversationIds(ctx, []string{data.ConversationId})
+	if err != nil || len(list) == 0 {
+		return 0, false, err
+	}
+	readSeq = min(readSeq, list[0].Seq)
+
+	changed, err := m.svcCtx.ConversationsModel.UpdateReadSeq(ctx, data.SendId, data.ConversationId, readSeq)
+	if err != nil || !changed {
+		return readSeq, false, err
+	}
+
+	// 计算新的未读数
+	conversations, err := m.svcCtx.ConversationsModel.FindByUserId(ctx, data.SendId)
+	if err != nil {
+		return readSeq, true, err
+	}
+	entry, ok := conversations.ConversationList[data.ConversationId]
+	if !ok {
+		return readSeq, true, nil
+	}
+
+	push := &ws.Push{
+		ConversationId: data.ConversationId,
+		ChatType:       data.ChatType,
+		SendId:         data.SendId,
+		ContentType:    constants.ContentUnread,
+		ReadSeq:        entry.ReadSeq,
+		Unread:         entry.UnreadCount(list[0]),
+	}
+	// 只推送给用户自己
+	if data.ChatType == constants.SingleChatType {
+		push.RecvId = data.SendId
+	} else {
+		push.RecvIds = []string{data.SendId}
+	}
+	if err := m.baseMsgTransfer.push(ctx, push); err != nil {
+		m.Errorf("push unread err: %v, uid: %v, conversationId: %v", err, data.SendId, data.ConversationId)
 	}
-	return result, nil
+	return entry.ReadSeq, true, nil
 }
 
 // 异步处理消息发送
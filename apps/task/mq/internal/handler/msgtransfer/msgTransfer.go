@@ -8,6 +8,7 @@ import (
 	"easy-chat/apps/task/mq/internal/svc"
 	"easy-chat/pkg/constants"
 	"github.com/zeromicro/go-zero/core/logx"
+	"time"
 )
 
 type baseMsgTransfer struct {
@@ -47,7 +48,7 @@ func (m *baseMsgTransfer) Transfer(ctx context.Context, data *ws.Push) error {
 
 // single 处理单聊消息的转发。
 //
-// 该方法通过 WebSocket 客户端将单聊消息推送给指定的用户。
+// 该方法将单聊消息推送给指定的用户。
 //
 // 参数:
 //   - ctx: 上下文对象，用于传递请求范围的数据。
@@ -56,13 +57,10 @@ func (m *baseMsgTransfer) Transfer(ctx context.Context, data *ws.Push) error {
 // 返回值:
 //   - error: 如果推送过程中出现错误，返回相应的错误；否则返回 nil。
 func (m *baseMsgTransfer) single(ctx context.Context, data *ws.Push) error {
+	m.markMuted(ctx, data, []string{data.RecvId})
+
 	// 推送消息
-	return m.svcCtx.WsClient.Send(websocket.Message{
-		FrameType: websocket.FrameData,
-		Method:    "push",
-		FormId:    constants.SystemRootUid,
-		Data:      data,
-	})
+	return m.push(ctx, data)
 }
 
 // group 处理群聊消息的转发。
@@ -78,24 +76,87 @@ func (m *baseMsgTransfer) single(ctx context.Context, data *ws.Push) error {
 //   - error: 如果查询群成员或推送消息过程中出现错误，返回相应的错误；否则返回 nil.
 func (m *baseMsgTransfer) group(ctx context.Context, data *ws.Push) error {
 	// 查询群用户
+	users, err := m.groupUsers(ctx, data.RecvId)
+	if err != nil {
+		return err
+	}
+	return m.pushGroup(ctx, data, users)
+}
+
+// groupUsers 查询群成员。
+func (m *baseMsgTransfer) groupUsers(ctx context.Context, groupId string) ([]*socialclient.GroupMembers, error) {
 	users, err := m.svcCtx.Social.GroupUsers(ctx, &socialclient.GroupUsersReq{
-		GroupId: data.RecvId,
+		GroupId: groupId,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return users.List, nil
+}
 
+// pushGroup 将群聊消息推送给除发送者外的群成员。
+//
+// 参数:
+//   - ctx: 上下文对象，用于传递请求范围的数据。
+//   - data: 包含要推送的数据的 Push 结构体。
+//   - users: 群成员列表。
+//
+// 返回值:
+//   - error: 如果推送过程中出现错误，返回相应的错误；否则返回 nil。
+func (m *baseMsgTransfer) pushGroup(ctx context.Context, data *ws.Push, users []*socialclient.GroupMembers) error {
 	// 获取待发送的群用户ID
-	data.RecvIds = make([]string, 0, len(users.List))
-	for _, user := range users.List {
+	data.RecvIds = make([]string, 0, len(users))
+	for _, user := range users {
 		// 不包含发送者自己
 		if user.UserId == data.SendId {
 			continue
 		}
 		data.RecvIds = append(data.RecvIds, user.UserId)
 	}
+	m.markMuted(ctx, data, data.RecvIds)
 
 	// 向用户发送消息
+	return m.push(ctx, data)
+}
+
+// markMuted 标记对会话开启了免打扰的接收者。
+//
+// 只有聊天消息需要提醒用户，免打扰的接收者仍会收到推送并计入未读，但推送中不再携带提醒标记。
+// 查询失败时不影响消息推送，所有接收者按未开启免打扰处理。
+//
+// 参数:
+//   - ctx: 上下文对象，用于传递请求范围的数据。
+//   - data: 包含要推送的数据的 Push 结构体。
+//   - recvIds: 接收者ID列表。
+func (m *baseMsgTransfer) markMuted(ctx context.Context, data *ws.Push, recvIds []string) {
+	if data.ContentType != constants.ContentChatMsg || len(recvIds) == 0 {
+		return
+	}
+
+	muted, err := m.svcCtx.ConversationsModel.ListMutedUserIds(ctx, data.ConversationId, recvIds, time.Now().UnixMilli())
+	if err != nil {
+		m.Errorf("list muted users err: %v, conversationId: %v", err, data.ConversationId)
+		return
+	}
+	data.Muted = muted
+}
+
+// push 将消息推送给接收者。
+//
+// 配置了跨节点路由时，根据连接注册表只向持有接收者连接的 im-ws 节点投递消息；
+// 否则通过 WebSocket 客户端将消息交给 im-ws 服务的 "push" 路由处理。
+//
+// 参数:
+//   - ctx: 上下文对象，用于传递请求范围的数据。
+//   - data: 包含要推送的数据的 Push 结构体。
+//
+// 返回值:
+//   - error: 如果推送过程中出现错误，返回相应的错误；否则返回 nil。
+func (m *baseMsgTransfer) push(ctx context.Context, data *ws.Push) error {
+	if m.svcCtx.Router != nil {
+		return m.svcCtx.Router.Deliver(ctx, data)
+	}
+
 	return m.svcCtx.WsClient.Send(websocket.Message{
 		FrameType: websocket.FrameData,
 		Method:    "push",
@@ -2,7 +2,9 @@ package svc
 
 import (
 	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/ws/gateway"
 	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
 	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/internal/config"
 	"easy-chat/pkg/constants"
@@ -15,21 +17,24 @@ type ServiceContext struct {
 	config.Config
 
 	WsClient websocket.Client
+	Router   *gateway.Router
 	*redis.Redis
 
 	socialclient.Social
 
 	immodels.ChatLogModel
 	immodels.ConversationModel
+	immodels.ConversationsModel
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	svc := &ServiceContext{
-		Config:            c,
-		Redis:             redis.MustNewRedis(c.Redisx),
-		ChatLogModel:      immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
-		ConversationModel: immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
-		Social:            socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
+		Config:             c,
+		Redis:              redis.MustNewRedis(c.Redisx),
+		ChatLogModel:       immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
+		ConversationModel:  immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
+		ConversationsModel: immodels.MustConversationsModel(c.Mongo.Url, c.Mongo.Db),
+		Social:             socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
 	}
 	// 获取token
 	token, err := svc.GetSystemToken()
@@ -41,8 +46,18 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	header := http.Header{}
 	header.Set("Authorization", token)
 
-	// 创建Websocket客户端，并设置 JWT 认证信息
-	svc.WsClient = websocket.NewClient(c.Ws.Host, websocket.WithClientHeader(header))
+	// 创建Websocket客户端，并设置 JWT 认证信息与消息编解码器
+	opts := []websocket.DialOptions{websocket.WithClientHeader(header)}
+	if c.Ws.Codec == websocket.ProtobufProtocol {
+		opts = append(opts, websocket.WithClientCodec(websocket.NewProtoCodec(ws.ProtoPayloads{})))
+	}
+	svc.WsClient = websocket.NewClient(c.Ws.Host, opts...)
+
+	// 配置了跨节点推送时，根据连接注册表将消息直接投递到接收者所在的节点
+	if len(c.MsgPushTransfer.Addrs) > 0 && c.MsgPushTransfer.Topic != "" {
+		svc.Router = gateway.NewRouter(gateway.NewRedisRegistry(svc.Redis, gateway.DefaultRouteTTL),
+			gateway.NewKafkaTransport(c.MsgPushTransfer.Addrs, c.MsgPushTransfer.Topic))
+	}
 	return svc
 }
 
@@ -1,6 +1,9 @@
 package mq
 
-import "easy-chat/pkg/constants"
+import (
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+)
 
 // MsgChatTransfer kafka消息格式
 type MsgChatTransfer struct {
@@ -12,8 +15,13 @@ type MsgChatTransfer struct {
 	SendTime           int64    `json:"sendTime"`
 
 	constants.MType `json:"mType"`
-	Content         string `json:"content"`
-	MsgId           string `json:"msgId"`
+	Content         string      `json:"content"`
+	Payload         *ws.Payload `json:"payload,omitempty"`    // 非文本消息的结构化内容
+	ReplyTo         string      `json:"replyTo,omitempty"`    // 引用回复的消息ID
+	Mentions        []string    `json:"mentions,omitempty"`   // 群聊中@的成员ID
+	MentionAll      bool        `json:"mentionAll,omitempty"` // 群聊中@所有人
+	MsgId           string      `json:"msgId"`
+	ChatLogId       string      `json:"chatLogId,omitempty"` // 聊天记录ID，由 im-ws 生成，消息重新投递时保持不变
 }
 
 // MsgMarkRead 处理已读消息
@@ -23,4 +31,20 @@ type MsgMarkRead struct {
 	SendId             string   `json:"sendId"`
 	RecvId             string   `json:"recvId"`
 	MsgIds             []string `json:"msgIds"`
+	Seq                int64    `json:"seq,omitempty"` // 已读到的消息序号，与 MsgIds 中的最大序号取较大者
+}
+
+// MsgNotice 已发送消息的变更通知，例如撤回、编辑、表情回应，由 ContentType 区分通知类型
+type MsgNotice struct {
+	constants.ContentType `json:"contentType"`
+	constants.ChatType    `json:"chatType"`
+	ConversationId        string `json:"conversationId"`
+	SendId                string `json:"sendId"` // 操作者ID
+	RecvId                string `json:"recvId"` // 原消息的接收者，群聊为群ID
+	MsgId                 string `json:"msgId"`
+	Seq                   int64  `json:"seq"`
+	Content               string `json:"content,omitempty"`  // 编辑后的消息内容
+	EditedAt              int64  `json:"editedAt,omitempty"` // 编辑时间
+
+	Reactions map[string][]string `json:"reactions,omitempty"` // 变更后消息的全部表情回应，键为表情
 }
@@ -0,0 +1,50 @@
+package mqclient
+
+import (
+	"easy-chat/apps/task/mq/mq"
+	"encoding/json"
+	"github.com/zeromicro/go-queue/kq"
+)
+
+// MsgNoticeTransferClient 提供发送消息变更通知的方法。
+//
+// 该接口定义了发送消息变更通知（例如撤回、编辑）的操作。
+type MsgNoticeTransferClient interface {
+	// Push 发送消息变更通知。
+	//
+	// 参数:
+	//   - msg: 消息变更通知，该通知将被发送到消息队列中。
+	//
+	// 返回值:
+	//   - error: 如果发送过程中出现错误，则返回相应的错误信息；否则返回 nil。
+	Push(msg *mq.MsgNotice) error
+}
+
+// msgNoticeTransferClient 实现了 MsgNoticeTransferClient 接口，用于将消息变更通知推送到消息队列中。
+type msgNoticeTransferClient struct {
+	pusher *kq.Pusher
+}
+
+// NewMsgNoticeTransferClient 创建一个新的 MsgNoticeTransferClient 实例。
+//
+// 参数:
+//   - addr: 消息队列的地址列表。
+//   - topic: 消息主题。
+//   - opts: 其他可选的推送选项。
+//
+// 返回值:
+//   - MsgNoticeTransferClient: 初始化好的消息推送客户端实例。
+func NewMsgNoticeTransferClient(addr []string, topic string, opts ...kq.PushOption) MsgNoticeTransferClient {
+	return &msgNoticeTransferClient{
+		pusher: kq.NewPusher(addr, topic, opts...),
+	}
+}
+
+// Push 将消息变更通知序列化为 JSON 格式，并推送到消息队列中。
+func (c *msgNoticeTransferClient) Push(msg *mq.MsgNotice) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.pusher.Push(string(body))
+}
@@ -1,3 +1,7 @@
+// Package bitmap 提供基于哈希的位图。
+//
+// 位图将ID哈希到固定大小的位数组中，不同的ID可能映射到同一位，IsSet 可能误报。
+// 聊天记录的已读信息已改为精确的已读用户列表，该包仅用于解析旧版本保存的已读记录。
 package bitmap
 
 // Bitmap 是一个位图结构，用于高效地表示和操作大量的布尔值。
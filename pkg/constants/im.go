@@ -5,7 +5,21 @@ type ChatType int
 type ContentType int
 
 const (
-	TextMType MType = iota
+	TextMType     MType = iota // 文本
+	ImageMType                 // 图片
+	FileMType                  // 文件
+	VoiceMType                 // 语音
+	VideoMType                 // 视频
+	LocationMType              // 地理位置
+	CardMType                  // 名片
+)
+
+// CardType 名片类型
+type CardType int
+
+const (
+	UserCardType  CardType = iota + 1 // 用户名片
+	GroupCardType                     // 群名片
 )
 
 const (
@@ -16,4 +30,18 @@ const (
 const (
 	ContentChatMsg ContentType = iota
 	ContentMakeRead
+	ContentRecall   // 消息撤回通知
+	ContentEdit     // 消息编辑通知
+	ContentReaction // 表情回应变更通知
+	ContentUnread   // 未读数变更通知，推送给用户自己的所有设备
+	ContentTyping   // 输入状态通知，临时信令，不落库也不需要确认送达
+	ContentPresence // 在线状态变更通知，临时信令，只推送给订阅了该用户在线状态的连接
+)
+
+// MsgStatus 消息状态
+type MsgStatus int
+
+const (
+	NormalMsgStatus   MsgStatus = iota // 正常
+	RecalledMsgStatus                  // 已撤回
 )
@@ -2,5 +2,21 @@ package constants
 
 const (
 	RedisSystemRootToken string = "system:root:token"
-	RedisOnlineUser      string = "online:user"
+	RedisWsRoute         string = "ws:route:"       // 用户设备连接所在的网关节点，后接用户ID
+	RedisWsUndelivered   string = "ws:undelivered:" // 未送达设备的推送消息，后接 用户ID:设备ID
+	RedisWsSignal        string = "ws:signal"       // 网关节点间转发临时信令的发布订阅频道前缀，实际频道为 前缀.节点ID
+)
+
+const (
+	RedisPresenceDevices  = "presence:devices:" // 用户各设备的心跳过期时间，后接用户ID
+	RedisPresenceExpiry   = "presence:expiry"   // 全部设备的心跳过期时间，用于清理心跳超时的设备
+	RedisPresenceOnline   = "presence:online"   // 已通知为在线的用户ID集合，用于判断上线/离线的状态转换
+	RedisPresenceLastSeen = "presence:lastseen" // 用户最后在线的时间，字段为用户ID
+)
+
+const (
+	RedisStorageUpload = "storage:upload:" // 分片上传的会话信息，后接上传ID
+	RedisStorageMeta   = "storage:meta:"   // 附件的元信息，后接附件ID
+	RedisStorageOwned  = "storage:owned:"  // 用户上传过的附件ID集合，后接用户ID
+	RedisStorageUsage  = "storage:usage"   // 用户已使用的存储空间，字段为用户ID
 )
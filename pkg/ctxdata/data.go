@@ -23,3 +23,19 @@ func GetUId(ctx context.Context) string {
 	// 如果获取失败，返回空字符串。
 	return ""
 }
+
+// GetDeviceId 从上下文中尝试获取设备ID。
+// 如果上下文中存在以DeviceIdentify为键的字符串值，则返回该值，否则返回空字符串。
+// 参数:
+//
+//	ctx - 上下文对象，用于传递请求范围内的值。
+//
+// 返回值:
+//
+//	设备ID的字符串表示，如果无法获取则为空字符串。
+func GetDeviceId(ctx context.Context) string {
+	if d, ok := ctx.Value(DeviceIdentify).(string); ok {
+		return d
+	}
+	return ""
+}
@@ -4,6 +4,9 @@ import "github.com/golang-jwt/jwt/v4"
 
 const Identify = "github/jmh000527"
 
+// DeviceIdentify 令牌中设备标识声明的键，用于区分同一用户的多个登入设备
+const DeviceIdentify = "deviceId"
+
 // GetJwtToken 根据给定的参数生成一个 JWT 令牌。
 //
 // 参数:
@@ -0,0 +1,225 @@
+// Package presence 基于 Redis 的用户在线状态。
+//
+// 在线状态由 im-ws 的连接生命周期驱动：设备连接建立时登记在线，之后由所在节点周期性刷新心跳，
+// 连接断开时注销。每个设备的心跳都有有效期，节点异常退出未能注销的设备在心跳过期后视为离线，
+// 并由任意节点的周期清理产生离线事件。
+//
+// 查询在线状态只看是否存在心跳未过期的设备；上线/离线的状态转换另外记录，
+// 保证每次转换只产生一次事件，供 im-ws 推送给订阅了该用户在线状态的好友。
+package presence
+
+import (
+	"context"
+	"easy-chat/pkg/constants"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"math"
+	"strconv"
+	"time"
+)
+
+// DefaultTTL 设备心跳的默认有效期，超过该时间未刷新心跳的设备视为离线。
+const DefaultTTL = 90 * time.Second
+
+// onlineScript 登记设备心跳，返回 1 表示用户由离线转为在线。
+var onlineScript = redis.NewScript(`
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[1])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+redis.call("ZADD", KEYS[2], ARGV[2], ARGV[3])
+return redis.call("SADD", KEYS[3], ARGV[5])
+`)
+
+// offlineScript 注销设备，用户不再有心跳未过期的设备时记录最后在线时间，返回 1 表示用户由在线转为离线。
+var offlineScript = redis.NewScript(`
+redis.call("ZREM", KEYS[2], ARGV[3])
+redis.call("ZREM", KEYS[1], ARGV[1])
+if redis.call("ZCOUNT", KEYS[1], "(" .. ARGV[2], "+inf") > 0 then
+	return 0
+end
+if redis.call("SREM", KEYS[3], ARGV[4]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[4], ARGV[4], ARGV[2])
+return 1
+`)
+
+// sweepScript 清理心跳过期的设备，返回因此转为离线的用户ID。
+var sweepScript = redis.NewScript(`
+local members = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[2])
+local offline = {}
+for _, member in ipairs(members) do
+	redis.call("ZREM", KEYS[1], member)
+	local uid = string.sub(member, 1, string.find(member, "/", 1, true) - 1)
+	local key = ARGV[3] .. uid
+	redis.call("ZREMRANGEBYSCORE", key, "-inf", ARGV[1])
+	if redis.call("ZCARD", key) == 0 and redis.call("SREM", KEYS[2], uid) == 1 then
+		redis.call("HSET", KEYS[3], uid, ARGV[1])
+		table.insert(offline, uid)
+	end
+end
+return offline
+`)
+
+// Status 用户的在线状态。
+type Status struct {
+	Online   bool  // 是否在线，即存在心跳未过期的设备
+	LastSeen int64 // 最后在线的时间戳（毫秒），在线时为当前时间，从未上线过为 0
+}
+
+// Store 在线状态存储。
+//
+// 每个用户对应一个有序集合，键为 RedisPresenceDevices + 用户ID，成员为 节点ID/设备ID，分值为心跳过期时间；
+// 全部设备的心跳过期时间另外记录在 RedisPresenceExpiry 中，用于清理节点异常退出后残留的设备。
+type Store struct {
+	rds *redis.Redis
+	ttl time.Duration
+}
+
+// NewStore 创建在线状态存储。
+//
+// 参数:
+//   - rds: Redis 客户端，im-ws 与查询在线状态的服务需使用同一个 Redis。
+//   - ttl: 设备心跳的有效期，小于等于 0 时使用 DefaultTTL。
+//
+// 返回值:
+//   - *Store: 在线状态存储实例。
+func NewStore(rds *redis.Redis, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{
+		rds: rds,
+		ttl: ttl,
+	}
+}
+
+// TTL 返回设备心跳的有效期，心跳需在有效期内刷新。
+func (s *Store) TTL() time.Duration {
+	return s.ttl
+}
+
+// Online 登记设备在线或刷新设备心跳。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - node: 设备连接所在的网关节点ID。
+//   - uid: 用户ID。
+//   - deviceId: 设备ID。
+//
+// 返回值:
+//   - bool: 用户是否由离线转为在线。
+//   - error: 操作 Redis 失败时返回错误。
+func (s *Store) Online(ctx context.Context, node, uid, deviceId string) (bool, error) {
+	expireAt := time.Now().Add(s.ttl).UnixMilli()
+	res, err := s.rds.ScriptRunCtx(ctx, onlineScript,
+		[]string{constants.RedisPresenceDevices + uid, constants.RedisPresenceExpiry, constants.RedisPresenceOnline},
+		device(node, deviceId), expireAt, expiryMember(node, uid, deviceId), s.ttl.Milliseconds(), uid)
+	if err != nil {
+		return false, err
+	}
+	return res.(int64) == 1, nil
+}
+
+// Offline 注销设备。
+//
+// 用户的其他设备仍然在线时不会转为离线；转为离线时记录最后在线时间。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - node: 设备连接所在的网关节点ID。
+//   - uid: 用户ID。
+//   - deviceId: 设备ID。
+//
+// 返回值:
+//   - int64: 用户转为离线时为最后在线时间（毫秒），否则为 0。
+//   - error: 操作 Redis 失败时返回错误。
+func (s *Store) Offline(ctx context.Context, node, uid, deviceId string) (int64, error) {
+	now := time.Now().UnixMilli()
+	res, err := s.rds.ScriptRunCtx(ctx, offlineScript,
+		[]string{constants.RedisPresenceDevices + uid, constants.RedisPresenceExpiry, constants.RedisPresenceOnline, constants.RedisPresenceLastSeen},
+		device(node, deviceId), now, expiryMember(node, uid, deviceId), uid)
+	if err != nil {
+		return 0, err
+	}
+	if res.(int64) != 1 {
+		return 0, nil
+	}
+	return now, nil
+}
+
+// Sweep 清理心跳过期的设备。
+//
+// 多个节点可以同时执行清理，每个过期设备只会被处理一次。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - limit: 本次最多清理的设备数量。
+//
+// 返回值:
+//   - []string: 因此转为离线的用户ID。
+//   - int64: 清理时间，即这些用户的最后在线时间（毫秒）。
+//   - error: 操作 Redis 失败时返回错误。
+func (s *Store) Sweep(ctx context.Context, limit int) ([]string, int64, error) {
+	now := time.Now().UnixMilli()
+	res, err := s.rds.ScriptRunCtx(ctx, sweepScript,
+		[]string{constants.RedisPresenceExpiry, constants.RedisPresenceOnline, constants.RedisPresenceLastSeen},
+		now, limit, constants.RedisPresenceDevices)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	vals, _ := res.([]any)
+	uids := make([]string, 0, len(vals))
+	for _, val := range vals {
+		if uid, ok := val.(string); ok {
+			uids = append(uids, uid)
+		}
+	}
+	return uids, now, nil
+}
+
+// Statuses 查询用户的在线状态。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - uids: 用户ID列表。
+//
+// 返回值:
+//   - map[string]Status: 用户ID到在线状态的映射。
+//   - error: 操作 Redis 失败时返回错误。
+func (s *Store) Statuses(ctx context.Context, uids ...string) (map[string]Status, error) {
+	res := make(map[string]Status, len(uids))
+	if len(uids) == 0 {
+		return res, nil
+	}
+
+	lastSeen, err := s.rds.HmgetCtx(ctx, constants.RedisPresenceLastSeen, uids...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	for i, uid := range uids {
+		// 只统计心跳未过期的设备
+		alive, err := s.rds.ZcountCtx(ctx, constants.RedisPresenceDevices+uid, now+1, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+		if alive > 0 {
+			res[uid] = Status{Online: true, LastSeen: now}
+			continue
+		}
+		seen, _ := strconv.ParseInt(lastSeen[i], 10, 64)
+		res[uid] = Status{LastSeen: seen}
+	}
+	return res, nil
+}
+
+// device 返回设备在用户有序集合中的成员名。
+func device(node, deviceId string) string {
+	return node + "/" + deviceId
+}
+
+// expiryMember 返回设备在全局过期集合中的成员名，用户ID在最前面，清理时据此找到用户。
+func expiryMember(node, uid, deviceId string) string {
+	return uid + "/" + device(node, deviceId)
+}
@@ -0,0 +1,89 @@
+package presence
+
+import "sync"
+
+// Subscriptions 记录订阅者订阅了哪些用户的在线状态。
+//
+// 订阅只保存在内存中，由 im-ws 节点记录本节点上各连接的订阅，连接断开时取消全部订阅。
+type Subscriptions struct {
+	mu          sync.RWMutex
+	targets     map[string]map[string]struct{} // 订阅者 -> 被订阅的用户ID
+	subscribers map[string]map[string]struct{} // 被订阅的用户ID -> 订阅者
+}
+
+// NewSubscriptions 创建在线状态订阅表。
+func NewSubscriptions() *Subscriptions {
+	return &Subscriptions{
+		targets:     make(map[string]map[string]struct{}),
+		subscribers: make(map[string]map[string]struct{}),
+	}
+}
+
+// Subscribe 订阅用户的在线状态，重复订阅不会产生影响。
+//
+// 参数:
+//   - subscriber: 订阅者，例如 im-ws 中连接的 用户ID/设备ID。
+//   - uids: 要订阅的用户ID。
+func (s *Subscriptions) Subscribe(subscriber string, uids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, uid := range uids {
+		add(s.targets, subscriber, uid)
+		add(s.subscribers, uid, subscriber)
+	}
+}
+
+// Unsubscribe 取消订阅用户的在线状态，未指定用户时取消订阅者的全部订阅。
+//
+// 参数:
+//   - subscriber: 订阅者。
+//   - uids: 要取消订阅的用户ID。
+func (s *Subscriptions) Unsubscribe(subscriber string, uids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(uids) == 0 {
+		for uid := range s.targets[subscriber] {
+			uids = append(uids, uid)
+		}
+	}
+	for _, uid := range uids {
+		remove(s.targets, subscriber, uid)
+		remove(s.subscribers, uid, subscriber)
+	}
+}
+
+// Subscribers 返回订阅了用户在线状态的订阅者。
+//
+// 参数:
+//   - uid: 用户ID。
+//
+// 返回值:
+//   - []string: 订阅者列表。
+func (s *Subscriptions) Subscribers(uid string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]string, 0, len(s.subscribers[uid]))
+	for subscriber := range s.subscribers[uid] {
+		res = append(res, subscriber)
+	}
+	return res
+}
+
+func add(m map[string]map[string]struct{}, key, val string) {
+	set, ok := m[key]
+	if !ok {
+		set = make(map[string]struct{})
+		m[key] = set
+	}
+	set[val] = struct{}{}
+}
+
+func remove(m map[string]map[string]struct{}, key, val string) {
+	delete(m[key], val)
+	if len(m[key]) == 0 {
+		delete(m, key)
+	}
+}
@@ -0,0 +1,32 @@
+package presence
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSubscriptions(t *testing.T) {
+	s := NewSubscriptions()
+	s.Subscribe("u1/ios", "u2", "u3")
+	s.Subscribe("u1/pc", "u2")
+	s.Subscribe("u1/pc", "u2")
+
+	got := s.Subscribers("u2")
+	sort.Strings(got)
+	if want := []string{"u1/ios", "u1/pc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Subscribers(u2) = %v, want %v", got, want)
+	}
+
+	s.Unsubscribe("u1/ios", "u3")
+	if got := s.Subscribers("u3"); len(got) != 0 {
+		t.Fatalf("Subscribers(u3) = %v, want empty", got)
+	}
+
+	// 未指定用户时取消全部订阅
+	s.Unsubscribe("u1/ios")
+	s.Unsubscribe("u1/pc")
+	if len(s.targets) != 0 || len(s.subscribers) != 0 {
+		t.Fatalf("subscriptions not cleared: %v %v", s.targets, s.subscribers)
+	}
+}
@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+)
+
+// maxImagePixels 允许解码的图片像素上限，避免超大尺寸的图片耗尽内存。
+const maxImagePixels = 50 * 1000 * 1000
+
+// ErrImageTooLarge 图片的像素数超过 maxImagePixels。
+var ErrImageTooLarge = errors.New("storage: image too large")
+
+// ImageInfo 图片的尺寸与缩略图。
+type ImageInfo struct {
+	Width  int    // 按 EXIF 方向摆正后的宽度
+	Height int    // 按 EXIF 方向摆正后的高度
+	Thumb  []byte // JPEG 格式的缩略图，已摆正且不含 EXIF 信息
+}
+
+// ImageMime 判断 MIME 类型是否为支持生成缩略图的图片格式。
+//
+// 参数:
+//   - mime: MIME 类型。
+//
+// 返回:
+//   - bool: 是否为 JPEG、PNG 或 GIF 图片。
+func ImageMime(mime string) bool {
+	switch mime {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// MakeThumbnail 解码图片，读取尺寸并生成缩略图。
+//
+// 仅使用标准库解码 JPEG、PNG 与 GIF（取第一帧）。JPEG 图片会读取 EXIF 中的方向信息，
+// 返回的尺寸与缩略图均为摆正后的结果，缩略图重新编码为不含 EXIF 的 JPEG，
+// 最长边不超过 maxSide，透明区域以白色填充。
+//
+// 参数:
+//   - r: 图片内容。
+//   - maxSide: 缩略图最长边的像素数。
+//
+// 返回:
+//   - *ImageInfo: 图片的尺寸与缩略图。
+//   - error: 图片格式不支持、尺寸过大或解码失败时返回错误。
+func MakeThumbnail(r io.ReadSeeker, maxSide int) (*ImageInfo, error) {
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		orientation = jpegOrientation(bufio.NewReader(r))
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先缩放再旋转，减少旋转的计算量
+	tw, th := fitSize(cfg.Width, cfg.Height, maxSide)
+	thumb := orient(resize(src, tw, th), orientation)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+
+	info := &ImageInfo{
+		Width:  cfg.Width,
+		Height: cfg.Height,
+		Thumb:  buf.Bytes(),
+	}
+	if orientation >= 5 {
+		// 方向 5~8 需要旋转 90 度，宽高互换
+		info.Width, info.Height = info.Height, info.Width
+	}
+	return info, nil
+}
+
+// fitSize 计算等比缩放后最长边不超过 maxSide 的尺寸，小于 maxSide 的图片保持原尺寸。
+func fitSize(w, h, maxSide int) (int, int) {
+	if w <= maxSide && h <= maxSide {
+		return w, h
+	}
+	if w >= h {
+		return maxSide, max(1, h*maxSide/w)
+	}
+	return max(1, w*maxSide/h), maxSide
+}
+
+// resize 使用区域平均的方式将图片缩放到指定尺寸，透明区域以白色填充。
+func resize(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+	// 统一转换为 RGBA 并铺上白色背景，便于按字节计算且去除透明通道
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Over)
+	if w == b.Dx() && h == b.Dy() {
+		return rgba
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	sw, sh := b.Dx(), b.Dy()
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, max((y+1)*sh/h, y*sh/h+1)
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, max((x+1)*sw/w, x*sw/w+1)
+
+			var r, g, bl, n int
+			for sy := y0; sy < y1; sy++ {
+				off := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(rgba.Pix[off])
+					g += int(rgba.Pix[off+1])
+					bl += int(rgba.Pix[off+2])
+					off += 4
+					n++
+				}
+			}
+			off := dst.PixOffset(x, y)
+			dst.Pix[off] = uint8(r / n)
+			dst.Pix[off+1] = uint8(g / n)
+			dst.Pix[off+2] = uint8(bl / n)
+			dst.Pix[off+3] = 0xff
+		}
+	}
+	return dst
+}
+
+// orient 按 EXIF 方向将图片摆正。
+//
+// 方向取值参见 EXIF 规范的 Orientation 标签：1 为正常，2~8 依次为各种镜像与旋转。
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平镜像
+				dx, dy = w-1-x, y
+			case 3: // 旋转 180 度
+				dx, dy = w-1-x, h-1-y
+			case 4: // 垂直镜像
+				dx, dy = x, h-1-y
+			case 5: // 沿左上-右下对角线镜像
+				dx, dy = y, x
+			case 6: // 顺时针旋转 90 度
+				dx, dy = h-1-y, x
+			case 7: // 沿右上-左下对角线镜像
+				dx, dy = h-1-y, w-1-x
+			case 8: // 逆时针旋转 90 度
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation 读取 JPEG 图片 EXIF 中的方向信息，读取失败或没有方向信息时返回 1。
+func jpegOrientation(r io.Reader) int {
+	var marker [2]byte
+	if _, err := io.ReadFull(r, marker[:]); err != nil || marker != [2]byte{0xff, 0xd8} {
+		return 1
+	}
+
+	for {
+		if _, err := io.ReadFull(r, marker[:]); err != nil || marker[0] != 0xff {
+			return 1
+		}
+		// 图像数据开始后不再有 EXIF 信息
+		if marker[1] == 0xda || marker[1] == 0xd9 {
+			return 1
+		}
+
+		var size uint16
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil || size < 2 {
+			return 1
+		}
+		seg := make([]byte, size-2)
+		if _, err := io.ReadFull(r, seg); err != nil {
+			return 1
+		}
+		if marker[1] == 0xe1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return exifOrientation(seg[6:])
+		}
+	}
+}
+
+// exifOrientation 从 EXIF 的 TIFF 数据中读取第一个 IFD 的 Orientation 标签。
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	n := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < n; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			v := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if v < 1 || v > 8 {
+				return 1
+			}
+			return v
+		}
+	}
+	return 1
+}
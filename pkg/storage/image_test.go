@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func TestMakeThumbnail(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	// 左半边为红色，右半边透明
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 400; x++ {
+			src.Set(x, y, color.NRGBA{R: 0xff, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := MakeThumbnail(bytes.NewReader(buf.Bytes()), 200)
+	if err != nil {
+		t.Fatalf("MakeThumbnail() err = %v", err)
+	}
+	if info.Width != 800 || info.Height != 400 {
+		t.Errorf("size = %dx%d, want 800x400", info.Width, info.Height)
+	}
+
+	thumb, err := jpeg.Decode(bytes.NewReader(info.Thumb))
+	if err != nil {
+		t.Fatalf("decode thumb err = %v", err)
+	}
+	if b := thumb.Bounds(); b.Dx() != 200 || b.Dy() != 100 {
+		t.Errorf("thumb size = %dx%d, want 200x100", b.Dx(), b.Dy())
+	}
+	// 透明区域以白色填充
+	if r, g, b, _ := thumb.At(150, 50).RGBA(); r>>8 < 0xf0 || g>>8 < 0xf0 || b>>8 < 0xf0 {
+		t.Errorf("transparent area = (%d,%d,%d), want white", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestMakeThumbnail_Orientation(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 60, 30))
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, src, nil); err != nil {
+		t.Fatal(err)
+	}
+	// 在 SOI 之后插入方向为 6（顺时针旋转 90 度）的 EXIF 段
+	data := append([]byte{0xff, 0xd8}, exifSegment(6)...)
+	data = append(data, buf.Bytes()[2:]...)
+
+	info, err := MakeThumbnail(bytes.NewReader(data), 320)
+	if err != nil {
+		t.Fatalf("MakeThumbnail() err = %v", err)
+	}
+	if info.Width != 30 || info.Height != 60 {
+		t.Errorf("size = %dx%d, want 30x60", info.Width, info.Height)
+	}
+	thumb, err := jpeg.Decode(bytes.NewReader(info.Thumb))
+	if err != nil {
+		t.Fatalf("decode thumb err = %v", err)
+	}
+	if b := thumb.Bounds(); b.Dx() != 30 || b.Dy() != 60 {
+		t.Errorf("thumb size = %dx%d, want 30x60", b.Dx(), b.Dy())
+	}
+}
+
+// exifSegment 构造只包含 Orientation 标签的 APP1 段。
+func exifSegment(orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	binary.Write(&tiff, binary.BigEndian, uint16(42))
+	binary.Write(&tiff, binary.BigEndian, uint32(8))
+	binary.Write(&tiff, binary.BigEndian, uint16(1))
+	binary.Write(&tiff, binary.BigEndian, uint16(0x0112))
+	binary.Write(&tiff, binary.BigEndian, uint16(3))
+	binary.Write(&tiff, binary.BigEndian, uint32(1))
+	binary.Write(&tiff, binary.BigEndian, orientation)
+	binary.Write(&tiff, binary.BigEndian, uint16(0))
+	binary.Write(&tiff, binary.BigEndian, uint32(0))
+
+	body := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	seg := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(body)+2))
+	return append(seg, body...)
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// localBackend 基于本地磁盘的存储后端。
+//
+// 对象按ID的前两级前缀分目录保存，例如 root/objects/ab/cd/abcd...，避免单个目录下文件过多。
+type localBackend struct {
+	root string
+}
+
+// NewLocal 创建一个基于本地磁盘的存储后端。
+//
+// 参数:
+//   - root: 存储根目录，不存在时自动创建。
+//
+// 返回:
+//   - Backend: 存储后端实例。
+//   - error: 创建目录失败时返回错误。
+func NewLocal(root string) (Backend, error) {
+	for _, dir := range []string{"objects", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &localBackend{root: root}, nil
+}
+
+func (b *localBackend) Put(ctx context.Context, id string, r io.Reader) error {
+	path := b.path(id)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// 先写入临时文件再重命名，保证读取方不会看到写了一半的对象
+	tmp, err := os.CreateTemp(filepath.Join(b.root, "tmp"), id+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (b *localBackend) Open(ctx context.Context, id string) (Object, error) {
+	f, err := os.Open(b.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &localObject{File: f, fi: fi}, nil
+}
+
+func (b *localBackend) Exists(ctx context.Context, id string) (bool, error) {
+	_, err := os.Stat(b.path(id))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// path 返回对象在磁盘上的路径。
+func (b *localBackend) path(id string) string {
+	return filepath.Join(b.root, "objects", id[:2], id[2:4], id)
+}
+
+// localObject 本地磁盘上的对象。
+type localObject struct {
+	*os.File
+	fi os.FileInfo
+}
+
+func (o *localObject) Size() int64 {
+	return o.fi.Size()
+}
+
+func (o *localObject) ModTime() time.Time {
+	return o.fi.ModTime()
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalBackend(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal() err = %v", err)
+	}
+
+	content := "hello easy-chat"
+	id, err := Sum(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Sum() err = %v", err)
+	}
+	if !ValidId(id) {
+		t.Fatalf("ValidId(%q) = false", id)
+	}
+
+	if ok, _ := backend.Exists(ctx, id); ok {
+		t.Fatalf("Exists() before Put = true")
+	}
+	if _, err := backend.Open(ctx, id); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Open() before Put err = %v, want ErrNotFound", err)
+	}
+
+	if err := backend.Put(ctx, id, strings.NewReader(content)); err != nil {
+		t.Fatalf("Put() err = %v", err)
+	}
+	if ok, _ := backend.Exists(ctx, id); !ok {
+		t.Fatalf("Exists() after Put = false")
+	}
+
+	obj, err := backend.Open(ctx, id)
+	if err != nil {
+		t.Fatalf("Open() err = %v", err)
+	}
+	defer obj.Close()
+	if obj.Size() != int64(len(content)) {
+		t.Errorf("Size() = %d, want %d", obj.Size(), len(content))
+	}
+
+	// 按 Range 读取需要支持随机访问
+	if _, err := obj.Seek(6, io.SeekStart); err != nil {
+		t.Fatalf("Seek() err = %v", err)
+	}
+	got, _ := io.ReadAll(obj)
+	if string(got) != content[6:] {
+		t.Errorf("read after Seek = %q, want %q", got, content[6:])
+	}
+}
+
+func TestValidId(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{strings.Repeat("a", 64), true},
+		{strings.Repeat("A", 64), false},
+		{strings.Repeat("a", 63), false},
+		{"../" + strings.Repeat("a", 61), false},
+	}
+	for _, tt := range tests {
+		if got := ValidId(tt.id); got != tt.want {
+			t.Errorf("ValidId(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
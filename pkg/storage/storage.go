@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound 存储后端中不存在指定的对象。
+var ErrNotFound = errors.New("storage: object not found")
+
+// Object 存储后端中的一个对象，支持随机读取以便按 Range 提供下载。
+type Object interface {
+	io.ReadSeekCloser
+	// Size 返回对象的大小，单位字节。
+	Size() int64
+	// ModTime 返回对象的写入时间。
+	ModTime() time.Time
+}
+
+// Backend 附件的存储后端。
+//
+// 对象以内容的 sha256 作为ID保存，相同内容只保存一份。
+// 目前提供本地磁盘的实现，兼容 S3 的对象存储可以实现同样的接口接入。
+type Backend interface {
+	// Put 保存对象，id 为内容的 sha256，已存在的对象会被覆盖。
+	Put(ctx context.Context, id string, r io.Reader) error
+	// Open 打开对象用于读取，对象不存在时返回 ErrNotFound。
+	Open(ctx context.Context, id string) (Object, error)
+	// Exists 判断对象是否存在。
+	Exists(ctx context.Context, id string) (bool, error)
+}
+
+// ValidId 判断 id 是否为合法的对象ID，即 64 位小写十六进制的 sha256。
+//
+// 参数:
+//   - id: 待校验的对象ID。
+//
+// 返回:
+//   - bool: 是否为合法的对象ID。
+func ValidId(id string) bool {
+	if len(id) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range id {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Sum 计算内容的对象ID。
+//
+// 参数:
+//   - r: 要计算的内容。
+//
+// 返回:
+//   - string: 内容的 sha256 十六进制字符串。
+//   - error: 读取内容失败时返回错误。
+func Sum(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
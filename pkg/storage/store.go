@@ -0,0 +1,637 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// stagingSweepInterval 清理过期暂存文件的间隔
+const stagingSweepInterval = time.Hour
+
+var (
+	ErrFileTooLarge     = xerr.NewMsg("文件大小超过限制")
+	ErrQuotaExceeded    = xerr.NewMsg("存储空间不足")
+	ErrUploadNotFound   = xerr.NewMsg("上传任务不存在或已过期")
+	ErrUploadOffset     = xerr.NewMsg("分片的偏移量与已上传的大小不一致")
+	ErrUploadIncomplete = xerr.NewMsg("文件尚未上传完成")
+	ErrUploadBusy       = xerr.NewMsg("上传任务正在写入，请稍后重试")
+	ErrFileNotFound     = xerr.NewMsg("附件不存在")
+)
+
+// Config 附件存储的配置。
+type Config struct {
+	Root         string        // 本地存储根目录，同时用于保存上传中的分片
+	ChunkSize    int64         `json:",default=4194304"`    // 建议的分片大小，单位字节
+	MaxFileSize  int64         `json:",default=104857600"`  // 单个文件的大小上限，单位字节
+	Quota        int64         `json:",default=1073741824"` // 每个用户的存储空间上限，单位字节
+	UploadExpire time.Duration `json:",default=24h"`        // 上传任务的有效期
+	ThumbSize    int           `json:",default=320"`        // 图片缩略图最长边的像素数
+}
+
+// Meta 附件的元信息。
+type Meta struct {
+	Id   string // 附件ID，内容的 sha256
+	Name string // 首次上传时的文件名
+	Mime string // 文件的 MIME 类型
+	Size int64  // 文件大小，单位字节
+
+	Width  int    // 图片摆正后的宽度，非图片为 0
+	Height int    // 图片摆正后的高度，非图片为 0
+	Thumb  string // 图片缩略图的附件ID，非图片为空
+}
+
+// Upload 分片上传任务。
+type Upload struct {
+	Id     string // 上传ID
+	Owner  string // 上传者的用户ID
+	Name   string // 文件名
+	Mime   string // 文件的 MIME 类型
+	Size   int64  // 文件大小，单位字节
+	Offset int64  // 已上传的大小，客户端从该位置继续上传
+}
+
+// Store 附件存储服务。
+//
+// 客户端先创建上传任务，再按顺序上传分片，中断后可查询已上传的大小并从该位置继续上传，
+// 全部上传后完成任务，文件以内容的 sha256 作为附件ID保存到存储后端。
+// 上传任务的信息、附件的元信息与用户的空间用量保存在 redis 中，
+// 上传中的分片暂存在本地磁盘，同一个上传任务的分片需要发送到同一个服务节点，
+// 上传任务过期后遗留的暂存文件会被定期清理。
+type Store struct {
+	c       Config
+	backend Backend
+	rds     *redis.Redis
+	staging string
+}
+
+// NewStore 创建附件存储服务。
+//
+// 参数:
+//   - c: 附件存储配置。
+//   - backend: 存储后端。
+//   - rds: redis 客户端。
+//
+// 返回:
+//   - *Store: 附件存储服务实例。
+//   - error: 创建分片暂存目录失败时返回错误。
+func NewStore(c Config, backend Backend, rds *redis.Redis) (*Store, error) {
+	staging := filepath.Join(c.Root, "uploads")
+	if err := os.MkdirAll(staging, 0o755); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		c:       c,
+		backend: backend,
+		rds:     rds,
+		staging: staging,
+	}
+	threading.GoSafe(s.sweepStaging)
+	return s, nil
+}
+
+// MustNewStore 创建基于本地磁盘的附件存储服务，失败时退出。
+//
+// 参数:
+//   - c: 附件存储配置。
+//   - rds: redis 客户端。
+//
+// 返回:
+//   - *Store: 附件存储服务实例。
+func MustNewStore(c Config, rds *redis.Redis) *Store {
+	backend, err := NewLocal(c.Root)
+	if err != nil {
+		panic(err)
+	}
+	s, err := NewStore(c, backend, rds)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ChunkSize 返回建议客户端使用的分片大小。
+func (s *Store) ChunkSize() int64 {
+	return s.c.ChunkSize
+}
+
+// CreateUpload 创建上传任务。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - owner: 上传者的用户ID。
+//   - name: 文件名。
+//   - mime: 文件的 MIME 类型。
+//   - size: 文件大小，单位字节。
+//
+// 返回:
+//   - *Upload: 创建的上传任务。
+//   - error: 文件过大、空间不足或保存失败时返回错误。
+func (s *Store) CreateUpload(ctx context.Context, owner, name, mime string, size int64) (*Upload, error) {
+	if size <= 0 || size > s.c.MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	used, err := s.Usage(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if used+size > s.c.Quota {
+		return nil, ErrQuotaExceeded
+	}
+
+	upload := &Upload{
+		Id:    newUploadId(),
+		Owner: owner,
+		Name:  name,
+		Mime:  mime,
+		Size:  size,
+	}
+	f, err := os.Create(s.stagingPath(upload.Id))
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+
+	if err := s.saveUpload(ctx, upload); err != nil {
+		os.Remove(s.stagingPath(upload.Id))
+		return nil, err
+	}
+	return upload, nil
+}
+
+// GetUpload 获取上传任务，用于断点续传时查询已上传的大小。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - owner: 上传者的用户ID。
+//   - id: 上传ID。
+//
+// 返回:
+//   - *Upload: 上传任务。
+//   - error: 上传任务不存在、已过期或不属于该用户时返回 ErrUploadNotFound。
+func (s *Store) GetUpload(ctx context.Context, owner, id string) (*Upload, error) {
+	if !validUploadId(id) {
+		return nil, ErrUploadNotFound
+	}
+	v, err := s.rds.HgetallCtx(ctx, constants.RedisStorageUpload+id)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 || v["owner"] != owner {
+		return nil, ErrUploadNotFound
+	}
+
+	size, _ := strconv.ParseInt(v["size"], 10, 64)
+	offset, _ := strconv.ParseInt(v["offset"], 10, 64)
+	return &Upload{
+		Id:     id,
+		Owner:  v["owner"],
+		Name:   v["name"],
+		Mime:   v["mime"],
+		Size:   size,
+		Offset: offset,
+	}, nil
+}
+
+// WriteChunk 写入一个分片。
+//
+// 分片必须从已上传的位置开始写入，超出文件大小的部分会被忽略。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - owner: 上传者的用户ID。
+//   - id: 上传ID。
+//   - offset: 分片在文件中的偏移量。
+//   - r: 分片内容。
+//
+// 返回:
+//   - *Upload: 写入后的上传任务。
+//   - error: 偏移量不一致、任务不存在或写入失败时返回错误。
+func (s *Store) WriteChunk(ctx context.Context, owner, id string, offset int64, r io.Reader) (*Upload, error) {
+	if !validUploadId(id) {
+		return nil, ErrUploadNotFound
+	}
+	lock := redis.NewRedisLock(s.rds, constants.RedisStorageUpload+id+":lock")
+	lock.SetExpire(60)
+	ok, err := lock.AcquireCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrUploadBusy
+	}
+	defer lock.ReleaseCtx(context.Background())
+
+	upload, err := s.GetUpload(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, ErrUploadOffset
+	}
+
+	f, err := os.OpenFile(s.stagingPath(id), os.O_WRONLY, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	// 丢弃上次中断时可能写入的不完整数据
+	if err := f.Truncate(offset); err != nil {
+		return nil, err
+	}
+	n, err := io.Copy(io.NewOffsetWriter(f, offset), io.LimitReader(r, upload.Size-offset))
+	upload.Offset += n
+	if n > 0 {
+		if err := s.rds.HsetCtx(ctx, constants.RedisStorageUpload+id, "offset", strconv.FormatInt(upload.Offset, 10)); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// CompleteUpload 完成上传任务，将文件保存到存储后端并计入用户的空间用量。
+//
+// 相同内容的文件只保存一份，同一用户重复上传相同内容的文件不会重复占用空间。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - owner: 上传者的用户ID。
+//   - id: 上传ID。
+//
+// 返回:
+//   - *Meta: 附件的元信息。
+//   - error: 文件未上传完成、空间不足或保存失败时返回错误。
+func (s *Store) CompleteUpload(ctx context.Context, owner, id string) (*Meta, error) {
+	upload, err := s.GetUpload(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Offset != upload.Size {
+		return nil, ErrUploadIncomplete
+	}
+
+	path := s.stagingPath(id)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fileId, err := Sum(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// 计入空间用量
+	added, err := s.rds.SaddCtx(ctx, constants.RedisStorageOwned+owner, fileId)
+	if err != nil {
+		return nil, err
+	}
+	if added > 0 {
+		used, err := s.rds.HincrbyCtx(ctx, constants.RedisStorageUsage, owner, int(upload.Size))
+		if err != nil {
+			return nil, err
+		}
+		if int64(used) > s.c.Quota {
+			s.rds.HincrbyCtx(ctx, constants.RedisStorageUsage, owner, -int(upload.Size))
+			s.rds.SremCtx(ctx, constants.RedisStorageOwned+owner, fileId)
+			return nil, ErrQuotaExceeded
+		}
+	}
+
+	// 保存文件，内容已存在时不再重复保存
+	exists, err := s.backend.Exists(ctx, fileId)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := s.backend.Put(ctx, fileId, f); err != nil {
+			return nil, err
+		}
+	}
+
+	// 内容相同的附件已经保存过元信息时直接使用，不再重复生成缩略图
+	meta, err := GetMeta(ctx, s.rds, fileId)
+	if err != nil && !errors.Is(err, ErrFileNotFound) {
+		return nil, err
+	}
+	if meta == nil {
+		meta = &Meta{
+			Id:   fileId,
+			Name: upload.Name,
+			Mime: upload.Mime,
+			Size: upload.Size,
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := s.imageMeta(ctx, meta, f); err != nil {
+			return nil, err
+		}
+		if err := s.saveMeta(ctx, meta); err != nil {
+			return nil, err
+		}
+	}
+
+	os.Remove(path)
+	s.rds.DelCtx(ctx, constants.RedisStorageUpload+id)
+	return meta, nil
+}
+
+// Open 打开附件用于下载。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - id: 附件ID。
+//
+// 返回:
+//   - Object: 附件内容，使用后需要关闭。
+//   - *Meta: 附件的元信息。
+//   - error: 附件不存在时返回 ErrFileNotFound。
+func (s *Store) Open(ctx context.Context, id string) (Object, *Meta, error) {
+	meta, err := GetMeta(ctx, s.rds, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	obj, err := s.backend.Open(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil, ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+	return obj, meta, nil
+}
+
+// GetMeta 获取附件的元信息。
+//
+// 只需要读取元信息的服务（例如 im-ws 为图片消息补充尺寸与缩略图）可以直接使用 redis 读取，
+// 无需创建 Store。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - rds: redis 客户端。
+//   - id: 附件ID。
+//
+// 返回:
+//   - *Meta: 附件的元信息。
+//   - error: 附件不存在时返回 ErrFileNotFound。
+func GetMeta(ctx context.Context, rds *redis.Redis, id string) (*Meta, error) {
+	if !ValidId(id) {
+		return nil, ErrFileNotFound
+	}
+	v, err := rds.HgetallCtx(ctx, constants.RedisStorageMeta+id)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, ErrFileNotFound
+	}
+
+	size, _ := strconv.ParseInt(v["size"], 10, 64)
+	width, _ := strconv.Atoi(v["width"])
+	height, _ := strconv.Atoi(v["height"])
+	return &Meta{
+		Id:     id,
+		Name:   v["name"],
+		Mime:   v["mime"],
+		Size:   size,
+		Width:  width,
+		Height: height,
+		Thumb:  v["thumb"],
+	}, nil
+}
+
+// Owned 判断用户是否上传过该附件，用于校验消息引用的附件ID，用户只能在消息中引用自己上传的附件。
+//
+// 与 GetMeta 相同，可以直接使用 redis 读取，无需创建 Store。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - rds: redis 客户端。
+//   - owner: 用户ID。
+//   - id: 附件ID。
+//
+// 返回:
+//   - bool: 用户是否上传过该附件。
+//   - error: 查询失败时返回错误。
+func Owned(ctx context.Context, rds *redis.Redis, owner, id string) (bool, error) {
+	if !ValidId(id) {
+		return false, nil
+	}
+	return rds.SismemberCtx(ctx, constants.RedisStorageOwned+owner, id)
+}
+
+// Exists 判断附件是否存在，用于校验消息引用的附件ID。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - id: 附件ID。
+//
+// 返回:
+//   - bool: 附件是否存在。
+//   - error: 查询失败时返回错误。
+func (s *Store) Exists(ctx context.Context, id string) (bool, error) {
+	if !ValidId(id) {
+		return false, nil
+	}
+	return s.rds.ExistsCtx(ctx, constants.RedisStorageMeta+id)
+}
+
+// Usage 返回用户已使用的存储空间。
+//
+// 参数:
+//   - ctx: 上下文。
+//   - owner: 用户ID。
+//
+// 返回:
+//   - int64: 已使用的存储空间，单位字节。
+//   - error: 查询失败时返回错误。
+func (s *Store) Usage(ctx context.Context, owner string) (int64, error) {
+	v, err := s.rds.HgetCtx(ctx, constants.RedisStorageUsage, owner)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// Quota 返回每个用户的存储空间上限。
+func (s *Store) Quota() int64 {
+	return s.c.Quota
+}
+
+// saveUpload 保存上传任务的信息并设置有效期。
+func (s *Store) saveUpload(ctx context.Context, upload *Upload) error {
+	key := constants.RedisStorageUpload + upload.Id
+	err := s.rds.HmsetCtx(ctx, key, map[string]string{
+		"owner":  upload.Owner,
+		"name":   upload.Name,
+		"mime":   upload.Mime,
+		"size":   strconv.FormatInt(upload.Size, 10),
+		"offset": strconv.FormatInt(upload.Offset, 10),
+	})
+	if err != nil {
+		return err
+	}
+	return s.rds.ExpireCtx(ctx, key, int(s.c.UploadExpire.Seconds()))
+}
+
+// saveMeta 保存附件的元信息，内容相同的附件保留首次上传时的信息。
+func (s *Store) saveMeta(ctx context.Context, meta *Meta) error {
+	key := constants.RedisStorageMeta + meta.Id
+	exists, err := s.rds.ExistsCtx(ctx, key)
+	if err != nil || exists {
+		return err
+	}
+	v := map[string]string{
+		"name": meta.Name,
+		"mime": meta.Mime,
+		"size": strconv.FormatInt(meta.Size, 10),
+	}
+	if meta.Thumb != "" {
+		v["width"] = strconv.Itoa(meta.Width)
+		v["height"] = strconv.Itoa(meta.Height)
+		v["thumb"] = meta.Thumb
+	}
+	return s.rds.HmsetCtx(ctx, key, v)
+}
+
+// imageMeta 识别图片附件，记录图片尺寸并生成缩略图，缩略图作为独立的附件保存。
+//
+// 文件类型根据内容识别，客户端未提供 MIME 类型时使用识别的结果。
+// 图片解码失败不影响附件的保存，此时附件按普通文件处理。
+func (s *Store) imageMeta(ctx context.Context, meta *Meta, r io.ReadSeeker) error {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+	mime := http.DetectContentType(head[:n])
+	if meta.Mime == "" {
+		meta.Mime = mime
+	}
+	if !ImageMime(mime) {
+		return nil
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	info, err := MakeThumbnail(r, s.c.ThumbSize)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("make thumbnail err: %v, id: %v", err, meta.Id)
+		return nil
+	}
+
+	thumb := &Meta{
+		Name: "thumb_" + meta.Id + ".jpg",
+		Mime: "image/jpeg",
+		Size: int64(len(info.Thumb)),
+	}
+	if thumb.Id, err = Sum(bytes.NewReader(info.Thumb)); err != nil {
+		return err
+	}
+	if err := s.backend.Put(ctx, thumb.Id, bytes.NewReader(info.Thumb)); err != nil {
+		return err
+	}
+	if err := s.saveMeta(ctx, thumb); err != nil {
+		return err
+	}
+
+	meta.Width = info.Width
+	meta.Height = info.Height
+	meta.Thumb = thumb.Id
+	return nil
+}
+
+// sweepStaging 定期清理过期上传任务遗留的暂存文件，启动时先清理一次上次运行遗留的文件。
+func (s *Store) sweepStaging() {
+	ticker := time.NewTicker(stagingSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.cleanStaging(time.Now().Add(-s.c.UploadExpire)); err != nil {
+			logx.Errorf("clean staging files err: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// cleanStaging 删除最后修改时间早于 before 的暂存文件。
+//
+// 上传任务自创建起 UploadExpire 后过期，暂存文件在创建任务时生成、写入分片时更新，
+// 最后修改时间早于过期时间的文件对应的上传任务必定已经过期，不会再被写入或完成。
+//
+// 参数:
+//   - before: 过期时间点，通常为当前时间减去 UploadExpire。
+//
+// 返回:
+//   - error: 读取暂存目录失败时返回错误。
+func (s *Store) cleanStaging(before time.Time) error {
+	entries, err := os.ReadDir(s.staging)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !validUploadId(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.staging, entry.Name())); err != nil && !os.IsNotExist(err) {
+			logx.Errorf("remove staging file err: %v, upload: %v", err, entry.Name())
+		}
+	}
+	return nil
+}
+
+// stagingPath 返回上传中的分片在本地磁盘上的暂存路径。
+func (s *Store) stagingPath(id string) string {
+	return filepath.Join(s.staging, id)
+}
+
+// newUploadId 生成随机的上传ID。
+func newUploadId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validUploadId 判断上传ID是否合法，上传ID同时用作暂存文件名，不能包含路径分隔符等字符。
+func validUploadId(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
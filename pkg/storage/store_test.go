@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreCleanStaging(t *testing.T) {
+	s := &Store{c: Config{UploadExpire: time.Hour}, staging: t.TempDir()}
+
+	expired, active := newUploadId(), newUploadId()
+	for _, id := range []string{expired, active} {
+		if err := os.WriteFile(s.stagingPath(id), []byte("chunk"), 0o644); err != nil {
+			t.Fatalf("WriteFile() err = %v", err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(s.stagingPath(expired), old, old); err != nil {
+		t.Fatalf("Chtimes() err = %v", err)
+	}
+	// 暂存目录中不是上传任务的文件不会被清理
+	other := filepath.Join(s.staging, "keep")
+	if err := os.WriteFile(other, nil, 0o644); err != nil {
+		t.Fatalf("WriteFile() err = %v", err)
+	}
+	if err := os.Chtimes(other, old, old); err != nil {
+		t.Fatalf("Chtimes() err = %v", err)
+	}
+
+	if err := s.cleanStaging(time.Now().Add(-s.c.UploadExpire)); err != nil {
+		t.Fatalf("cleanStaging() err = %v", err)
+	}
+	if _, err := os.Stat(s.stagingPath(expired)); !os.IsNotExist(err) {
+		t.Errorf("expired staging file still exists, err = %v", err)
+	}
+	for _, path := range []string{s.stagingPath(active), other} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Stat(%s) err = %v, want file kept", path, err)
+		}
+	}
+}